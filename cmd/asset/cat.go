@@ -0,0 +1,106 @@
+package asset
+
+import (
+	"fmt"
+	"os"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var CatCmd = &cobra.Command{
+	Use:   "cat [flags] <path>...",
+	Short: "Print the contents of files in Nexus repository",
+	Long: `Write the contents of files in Nexus OSS Raw Repository to stdout.
+Files are streamed without being saved or held in memory, so the output can be
+piped into other commands. Log messages go to stderr; use --quiet to silence them.
+
+Examples:
+  # Show a configuration file
+  nexus-util asset cat -a http://nexus.example.com -r myrepo -u user -p pass config/app.yaml
+
+  # Unpack an archive without saving it first
+  nexus-util asset cat -q -a http://nexus.example.com -r myrepo -u user -p pass dist/app.tar.gz | tar xz`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runCat,
+}
+
+func runCat(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	for _, path := range args {
+		if _, err := client.DownloadToWriter(ctx, repository, path, os.Stdout); err != nil {
+			return fmt.Errorf("failed to read '%s': %w", path, err)
+		}
+	}
+	return nil
+}
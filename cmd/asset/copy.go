@@ -0,0 +1,136 @@
+package asset
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var CopyCmd = &cobra.Command{
+	Use:     "cp [flags] <src> <dst>",
+	Aliases: []string{"copy"},
+	Short:   "Copy files or directories within Nexus repository",
+	Long: `Copy files or directories within Nexus OSS Raw Repository.
+A source ending with '/' is treated as a directory and all its files are copied
+under the destination prefix. Existing destination files are not replaced unless
+--overwrite is passed.
+
+Examples:
+  # Copy a single file
+  nexus-util asset cp -a http://nexus.example.com -r myrepo -u user -p pass dir/file.txt backup/file.txt
+
+  # Copy a directory to a new prefix
+  nexus-util asset cp -a http://nexus.example.com -r myrepo -u user -p pass releases/v1.0/ releases/latest/
+
+  # Replace files that already exist at the destination
+  nexus-util asset cp --overwrite -a http://nexus.example.com -r myrepo -u user -p pass releases/v1.1/ releases/latest/
+
+  # Dry run to see what would be copied
+  nexus-util asset cp --dry -a http://nexus.example.com -r myrepo -u user -p pass releases/v1.0/ releases/latest/`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCopy,
+}
+
+func runCopy(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get copy-specific flags
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	srcPath := args[0]
+	dstPath := args[1]
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	// Determine if it's a directory (ends with /)
+	isDir := strings.HasSuffix(srcPath, "/") || strings.HasSuffix(srcPath, "\\")
+
+	if isDir {
+		if err := client.CopyDirectory(ctx, repository, srcPath, dstPath, overwrite); err != nil {
+			return fmt.Errorf("failed to copy directory: %w", err)
+		}
+	} else {
+		if strings.HasSuffix(dstPath, "/") || strings.HasSuffix(dstPath, "\\") {
+			return fmt.Errorf("destination '%s' must be a file path when copying a file", dstPath)
+		}
+		if err := client.CopyFile(ctx, repository, srcPath, dstPath, overwrite); err != nil {
+			return fmt.Errorf("failed to copy file: %w", err)
+		}
+	}
+
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "Success!")
+	}
+
+	return nil
+}
@@ -1,8 +1,14 @@
 package asset
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"nexus-util/config"
 	"nexus-util/nexus"
@@ -15,36 +21,98 @@ var DeleteCmd = &cobra.Command{
 	Short: "Delete files or directories from Nexus repository",
 	Long: `Delete files or directories from Nexus OSS Raw Repository.
 This command combines the functionality of the original nexus_delete.py script.
+Before deleting directories the number of files below them is shown and a
+confirmation is requested; use --force to skip it.
 
 Examples:
   # Delete a single file
   nexus-util asset delete -a http://nexus.example.com -r myrepo -u user -p pass file.txt
 
-  # Delete a directory
+  # Delete a directory (asks for confirmation)
   nexus-util asset delete -a http://nexus.example.com -r myrepo -u user -p pass dir/
 
+  # Delete a directory without confirmation (for scripts)
+  nexus-util asset delete -a http://nexus.example.com -r myrepo -u user -p pass --force dir/
+
+  # Delete the files listed in a manifest, one path per line
+  nexus-util asset delete -a http://nexus.example.com -r myrepo -u user -p pass --from-file paths.txt
+
+  # Keep deleting after a failure and report all failures at the end
+  nexus-util asset delete -a http://nexus.example.com -r myrepo -u user -p pass --continue-on-error dir1/ dir2/
+
+  # Delete release files older than 90 days, previewing them first
+  nexus-util asset delete --dry -a http://nexus.example.com -r myrepo -u user -p pass --older-than 90d releases/
+  nexus-util asset delete -a http://nexus.example.com -r myrepo -u user -p pass --older-than 90d --force releases/
+
   # Dry run to see what would be deleted
   nexus-util asset delete --dry -a http://nexus.example.com -r myrepo -u user -p pass file.txt`,
-	Args: cobra.MinimumNArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if fromFile, _ := cmd.Flags().GetString("from-file"); fromFile != "" {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: runDelete,
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get common flags
 	address, _ := cmd.Flags().GetString("address")
 	repository, _ := cmd.Flags().GetString("repository")
 	username, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	configPath, _ := cmd.Flags().GetString("config")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
 
+	// Get delete-specific flags
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+	force, _ := cmd.Flags().GetBool("force")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	newerThan, _ := cmd.Flags().GetString("newer-than")
+
+	age, err := nexus.ParseAgeFilter(olderThan, newerThan, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Read the manifest before connecting so a bad file fails fast
+	var manifest []string
+	if fromFile != "" {
+		manifest, err = readPathList(cmd, fromFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
 		"nexusAddress": address,
 		"user":         username,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
@@ -54,37 +122,205 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
 
 	// Create Nexus client
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	client.ContinueOnError = continueOnError
+
+	// With an age filter only the matching files below the paths are deleted
+	if age.Active() {
+		if err := deleteByAge(ctx, client, repository, append(args, manifest...), age, !force && !dryRun, cmd.InOrStdin(), os.Stderr); err != nil {
+			return err
+		}
+		printDeleted(client.BrowseURL(repository, ""), quiet)
+		return nil
+	}
+
+	// Ask before deleting whole directories unless forced; a dry run deletes nothing
+	if !force && !dryRun {
+		if err := confirmDirectoryDeletes(ctx, client, repository, args, cmd.InOrStdin(), os.Stderr); err != nil {
+			return err
+		}
+	}
 
-	// Process each path
+	// Process each path; with --continue-on-error failures are reported at the end
+	var errs []error
 	for _, path := range args {
 		client.Logf("Process path '%s'", path)
 
-		// Determine if it's a directory (ends with /)
-		isDir := strings.HasSuffix(path, "/") || strings.HasSuffix(path, "\\")
-
-		if isDir {
+		if isDirectoryPath(path) {
 			// Delete directory
-			if err := client.DeleteDirectory(repository, path); err != nil {
-				return fmt.Errorf("failed to delete directory: %w", err)
+			if err := client.DeleteDirectory(ctx, repository, path); err != nil {
+				if !continueOnError {
+					return fmt.Errorf("failed to delete directory: %w", err)
+				}
+				errs = append(errs, fmt.Errorf("failed to delete directory '%s': %w", path, err))
 			}
 		} else {
 			// Delete file
-			if err := client.DeleteFile(repository, path); err != nil {
-				return fmt.Errorf("failed to delete file: %w", err)
+			if err := client.DeleteFile(ctx, repository, path); err != nil {
+				if !continueOnError {
+					return fmt.Errorf("failed to delete file: %w", err)
+				}
+				client.Errorf("Failed to delete '%s': %v", path, err)
+				errs = append(errs, fmt.Errorf("failed to delete file '%s': %w", path, err))
 			}
 		}
 	}
 
-	// Print browse URL
-	linkURL := fmt.Sprintf("%s/#browse/browse:%s", cfg.GetNexusAddress(), repository)
+	// Delete the files from the manifest as one batch
+	if fromFile != "" {
+		client.Logf("Deleting %d files listed in '%s'", len(manifest), fromFile)
+		if err := client.DeleteFiles(ctx, repository, manifest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	printDeleted(client.BrowseURL(repository, ""), quiet)
+	return nil
+}
+
+// printDeleted prints the browse URL of the repository after a successful delete
+func printDeleted(linkURL string, quiet bool) {
 	fmt.Println(linkURL)
 
 	if !quiet {
-		fmt.Println("Success!")
+		fmt.Fprintln(os.Stderr, "Success!")
 	}
+}
 
-	return nil
+// deleteByAge deletes, as one batch, the files named by paths or below the
+// directories among them whose last modification time matches age. When
+// confirm is set and a directory is involved, the number of matching files is
+// shown and a confirmation is requested on in.
+func deleteByAge(ctx context.Context, client *nexus.NexusClient, repository string, paths []string, age nexus.AgeFilter, confirm bool, in io.Reader, out io.Writer) error {
+	var files []nexus.Asset
+	hasDirectory := false
+	for _, path := range paths {
+		if !isDirectoryPath(path) {
+			files = append(files, nexus.Asset{Path: path})
+			continue
+		}
+		hasDirectory = true
+		dirFiles, err := client.GetFilesInDirectory(ctx, repository, strings.TrimRight(path, "/\\"))
+		if err != nil {
+			return fmt.Errorf("failed to get files in directory: %w", err)
+		}
+		files = append(files, dirFiles...)
+	}
+
+	matched := client.FilterByAge(ctx, repository, files, age)
+	if len(matched) == 0 {
+		client.Logf("None of the %d files matches the age filter, nothing to delete", len(files))
+		return nil
+	}
+	if confirm && hasDirectory {
+		fmt.Fprintf(out, "About to delete %d of %d files under %s\n", len(matched), len(files), strings.Join(paths, ", "))
+		if err := askConfirmation(in, out); err != nil {
+			return err
+		}
+	}
+
+	matchedPaths := make([]string, len(matched))
+	for i, file := range matched {
+		matchedPaths[i] = file.Path
+	}
+	client.Logf("Deleting %d of %d files matching the age filter", len(matched), len(files))
+	return client.DeleteFiles(ctx, repository, matchedPaths)
+}
+
+// isDirectoryPath reports whether a delete argument names a directory (ends with a slash)
+func isDirectoryPath(path string) bool {
+	return strings.HasSuffix(path, "/") || strings.HasSuffix(path, "\\")
+}
+
+// confirmDirectoryDeletes prints how many files each directory in paths holds
+// and asks on in whether to continue. It returns an error unless the answer is yes.
+func confirmDirectoryDeletes(ctx context.Context, client *nexus.NexusClient, repository string, paths []string, in io.Reader, out io.Writer) error {
+	total := 0
+	for _, path := range paths {
+		if !isDirectoryPath(path) {
+			continue
+		}
+		dir := strings.TrimRight(path, "/\\")
+		files, err := client.GetFilesInDirectory(ctx, repository, dir)
+		if err != nil {
+			return fmt.Errorf("failed to get files in directory: %w", err)
+		}
+		fmt.Fprintf(out, "About to delete %d files under %s\n", len(files), path)
+		total += len(files)
+	}
+	if total == 0 {
+		return nil
+	}
+	return askConfirmation(in, out)
+}
+
+// askConfirmation asks on in whether to continue and returns an error unless
+// the answer is yes
+func askConfirmation(in io.Reader, out io.Writer) error {
+	fmt.Fprint(out, "Continue? [y/N]: ")
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return fmt.Errorf("error reading confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("deletion not confirmed, nothing deleted")
+	}
+}
+
+// readPathList reads newline-separated asset paths from a file, or from stdin
+// when path is "-". Blank lines and lines starting with '#' are ignored.
+func readPathList(cmd *cobra.Command, path string) ([]string, error) {
+	var r io.Reader
+	if path == "-" {
+		r = cmd.InOrStdin()
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open path list: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read path list: %w", err)
+	}
+	return paths, nil
 }
@@ -1,6 +1,7 @@
 package asset
 
 import (
+	"context"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -15,11 +16,13 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"nexus-util/config"
 	"nexus-util/nexus"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var DiffCmd = &cobra.Command{
@@ -29,8 +32,12 @@ var DiffCmd = &cobra.Command{
 The command can compare:
   - Two Nexus repositories (possibly on different servers)
   - One Nexus repository and a local directory
+  - One Nexus repository and the files in a .tar, .tar.gz/.tgz or .zip archive
 
-Output is always JSON with file lists grouped by comparison result.
+Output is always JSON with file lists grouped by comparison result, a
+summary object with the number of files in each group and a comparison object
+naming the source (the --repository) and the target (--local, --archive or
+--target-*).
 
 Examples:
   # Compare two repositories on different servers
@@ -44,6 +51,18 @@ Examples:
   # Compare excluding a specific subdirectory
   nexus-util asset diff -a http://nexus.example.com -r repo1 \
     --path releases/v1.2.3 --local ./downloads --exclude releases/v1.2.3/temp
+
+  # Check that a repository directory matches a released bundle
+  nexus-util asset diff -a http://nexus.example.com -r repo1 --path releases/v1.2.3 --archive ./app-1.2.3.tar.gz
+
+  # Fail a CI job with exit code 3 when the repositories are out of sync
+  nexus-util asset diff -a http://nexus.example.com -r repo1 --target-repo repo2 --exit-code
+
+  # Hash files without reported checksums 8 at a time
+  nexus-util asset diff -a http://nexus.example.com -r repo1 --path releases --local ./downloads --parallel 8
+
+  # Always compare sha256, downloading files that only have weaker checksums
+  nexus-util asset diff -a http://nexus.example.com -r repo1 --target-repo repo2 --algorithm sha256
 `,
 	Args: cobra.NoArgs,
 	RunE: runDiff,
@@ -54,6 +73,33 @@ type diffResult struct {
 	OnlySource []string       `json:"only_source"`
 	OnlyTarget []string       `json:"only_target"`
 	Different  []diffMismatch `json:"different"`
+	Summary    diffSummary    `json:"summary"`
+	Comparison diffComparison `json:"comparison"`
+}
+
+// diffComparison names the two sides that only_source and only_target refer to
+type diffComparison struct {
+	Source diffSide `json:"source"`
+	Target diffSide `json:"target"`
+}
+
+// diffSide describes one side of a comparison: a Nexus repository path or a
+// local directory
+type diffSide struct {
+	Type       string `json:"type"`
+	Address    string `json:"address,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Path       string `json:"path"`
+}
+
+// diffSummary counts the entries of each diffResult list; Total is the number
+// of distinct paths found on either side
+type diffSummary struct {
+	Identical  int `json:"identical"`
+	OnlySource int `json:"only_source"`
+	OnlyTarget int `json:"only_target"`
+	Different  int `json:"different"`
+	Total      int `json:"total"`
 }
 
 type diffFile struct {
@@ -73,16 +119,27 @@ type fileEntry struct {
 	RelativePath string
 	Asset        *nexus.Asset
 	LocalPath    string
+	// Checksums holds the hashes of archive entries, computed while the archive is read
+	Checksums map[string]string
 }
 
-var hashPreference = []string{"sha256", "sha1", "md5"}
-
 func runDiff(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
 	// Source (default) flags
 	address, _ := cmd.Flags().GetString("address")
 	repository, _ := cmd.Flags().GetString("repository")
 	username, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	configPath, _ := cmd.Flags().GetString("config")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
@@ -92,23 +149,44 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 	targetRepo, _ := cmd.Flags().GetString("target-repo")
 	targetUser, _ := cmd.Flags().GetString("target-user")
 	targetPass, _ := cmd.Flags().GetString("target-pass")
+	targetToken, _ := cmd.Flags().GetString("target-token")
 	localDir, _ := cmd.Flags().GetString("local")
+	archivePath, _ := cmd.Flags().GetString("archive")
 	pathFlag, _ := cmd.Flags().GetString("path")
 	excludeDir, _ := cmd.Flags().GetString("exclude")
+	algorithm, _ := cmd.Flags().GetString("algorithm")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+
+	if parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+
+	// Validating a forced checksum algorithm
+	if algorithm != "" {
+		algorithm = strings.ToLower(algorithm)
+		if _, err := newLocalHashForAlgorithm(algorithm); err != nil {
+			return fmt.Errorf("%w, use sha256, sha1 or md5", err)
+		}
+	}
 
 	// Definening the work scenario
 	var scenario string
-	if localDir != "" {
+	if localDir != "" && archivePath != "" {
+		return fmt.Errorf("use either --local or --archive, not both")
+	} else if localDir != "" {
 		scenario = "local"
+	} else if archivePath != "" {
+		scenario = "archive"
 	} else if targetAddress != "" || targetRepo != "" {
 		scenario = "nexus-to-nexus"
 	} else {
-		return fmt.Errorf("must specify either --local or --target-* flags")
+		return fmt.Errorf("must specify either --local, --archive or --target-* flags")
 	}
 
 	// Checking for conflicting flags
-	if scenario == "local" && (targetAddress != "" || targetRepo != "" || targetUser != "" || targetPass != "") {
-		return fmt.Errorf("use either --local or --target-* flags, not both")
+	if scenario != "nexus-to-nexus" && (targetAddress != "" || targetRepo != "" || targetUser != "" || targetPass != "" || targetToken != "") {
+		return fmt.Errorf("use either --%s or --target-* flags, not both", scenario)
 	}
 
 	// Load source config
@@ -116,10 +194,27 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 		"nexusAddress": address,
 		"user":         username,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
 	}
+	if err := cfg.ValidateTimeout(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
 
 	sourceAddress := address
 	if sourceAddress == "" {
@@ -140,6 +235,7 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 	if sourcePass == "" {
 		sourcePass = cfg.GetPassword()
 	}
+	sourceToken := cfg.GetToken()
 
 	if repository == "" {
 		return fmt.Errorf("source repository is required")
@@ -149,10 +245,30 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 	normalizedExclude := "/" + normalizeRepoPath(excludeDir)
 
 	// Always silence Nexus client logs to keep JSON clean.
-	sourceClient := nexus.NewNexusClient(sourceAddress, sourceUser, sourcePass, true, dryRun, insecure)
+	tlsOptions := nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	}
+	// Source and target share TLS and proxy settings, so they also share a connection pool
+	transport := nexus.NewTransport(insecure, 0)
+	sourceClient := nexus.NewNexusClientWithTransport(sourceAddress, sourceUser, sourcePass, true, dryRun, insecure, transport)
+	if err := sourceClient.ConfigureTLS(tlsOptions); err != nil {
+		return fmt.Errorf("error creating source Nexus client: %w", err)
+	}
+	sourceClient.Token = sourceToken
+	sourceClient.UserAgent = cfg.GetUserAgent()
+	sourceClient.BasePath = cfg.GetBasePath()
+	if err := sourceClient.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		sourceClient.SetTimeout(timeout)
+	}
+	sourceClient.SetConcurrency(parallel)
 
 	var sourceFiles map[string]fileEntry
-	sourceFiles, err = collectRepoFiles(sourceClient, repository, normalizedPath)
+	sourceFiles, err = collectRepoFiles(ctx, sourceClient, repository, normalizedPath)
 	if err != nil {
 		return fmt.Errorf("failed to load source repository files: %w", err)
 	}
@@ -164,6 +280,9 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 
 	var targetFiles map[string]fileEntry
 	var targetClient *nexus.NexusClient
+	comparison := diffComparison{
+		Source: diffSide{Type: "nexus", Address: sourceAddress, Repository: repository, Path: normalizeRepoPath(pathFlag)},
+	}
 
 	switch scenario {
 	case "local":
@@ -171,6 +290,7 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 		if normalizedPath != "" {
 			localRoot = filepath.Join(localDir, filepath.FromSlash(normalizedPath))
 		}
+		comparison.Target = diffSide{Type: "local", Path: localRoot}
 		targetFiles, err = collectLocalFiles(localRoot)
 		if err != nil {
 			return fmt.Errorf("failed to load local files: %w", err)
@@ -181,6 +301,18 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 			targetFiles = filterExcludedFiles(targetFiles, normalizedExclude)
 		}
 
+	case "archive":
+		comparison.Target = diffSide{Type: "archive", Path: archivePath}
+		targetFiles, err = collectArchiveFiles(archivePath, normalizeRepoPath(pathFlag))
+		if err != nil {
+			return fmt.Errorf("failed to load archive files: %w", err)
+		}
+
+		// Applying an exclusion to archive files
+		if normalizedExclude != "" {
+			targetFiles = filterExcludedFiles(targetFiles, normalizedExclude)
+		}
+
 	case "nexus-to-nexus":
 		// Setting up the target client
 		if targetAddress == "" {
@@ -192,14 +324,28 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 		if targetPass == "" {
 			targetPass = cfg.GetPassword()
 		}
+		if targetToken == "" {
+			targetToken = sourceToken
+		}
 
 		// Check that the target repository is specified
 		if targetRepo == "" {
 			return fmt.Errorf("target repository is required when comparing repositories")
 		}
 
-		targetClient = nexus.NewNexusClient(targetAddress, targetUser, targetPass, true, dryRun, insecure)
-		targetFiles, err = collectRepoFiles(targetClient, targetRepo, normalizedPath)
+		targetClient = nexus.NewNexusClientWithTransport(targetAddress, targetUser, targetPass, true, dryRun, insecure, transport)
+		targetClient.Token = targetToken
+		targetClient.UserAgent = cfg.GetUserAgent()
+		targetClient.BasePath = cfg.GetBasePath()
+		if err := targetClient.SetProxy(cfg.GetProxy()); err != nil {
+			return fmt.Errorf("error configuring proxy: %w", err)
+		}
+		if timeout, ok := cfg.GetTimeout(); ok {
+			targetClient.SetTimeout(timeout)
+		}
+		targetClient.SetConcurrency(parallel)
+		comparison.Target = diffSide{Type: "nexus", Address: targetAddress, Repository: targetRepo, Path: normalizeRepoPath(pathFlag)}
+		targetFiles, err = collectRepoFiles(ctx, targetClient, targetRepo, normalizedPath)
 		if err != nil {
 			return fmt.Errorf("failed to load target repository files: %w", err)
 		}
@@ -211,6 +357,32 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 
 	}
 
+	result, err := compareFiles(ctx, sourceFiles, targetFiles, sourceClient, targetClient, algorithm, parallel)
+	if err != nil {
+		return err
+	}
+	result.Comparison = comparison
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+
+	// The JSON is written first so that CI jobs get the details as well
+	if exitCode && result.Summary.Total != result.Summary.Identical {
+		// Differences are a result, not a usage mistake
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%w: %d only in source, %d only in target, %d different", nexus.ErrDifferences,
+			result.Summary.OnlySource, result.Summary.OnlyTarget, result.Summary.Different)
+	}
+	return nil
+}
+
+// compareFiles compares the files present on both sides by checksum using
+// parallel workers, so that files without a reported checksum are downloaded
+// and hashed concurrently. The result lists are sorted by path.
+func compareFiles(ctx context.Context, sourceFiles, targetFiles map[string]fileEntry, sourceClient, targetClient *nexus.NexusClient, algorithm string, parallel int) (diffResult, error) {
 	result := diffResult{
 		Identical:  []diffFile{},
 		OnlySource: []string{},
@@ -218,32 +390,59 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 		Different:  []diffMismatch{},
 	}
 
-	for relPath, sourceEntry := range sourceFiles {
-		targetEntry, ok := targetFiles[relPath]
-		if !ok {
-			result.OnlySource = append(result.OnlySource, relPath)
-			continue
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	jobs := make(chan string)
+
+	g.Go(func() error {
+		defer close(jobs)
+		for relPath := range sourceFiles {
+			if _, ok := targetFiles[relPath]; !ok {
+				mu.Lock()
+				result.OnlySource = append(result.OnlySource, relPath)
+				mu.Unlock()
+				continue
+			}
+			select {
+			case jobs <- relPath:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
 		}
+		return nil
+	})
 
-		algorithm, sourceHash, targetHash, err := comparableHashes(sourceEntry, targetEntry, sourceClient, targetClient)
-		if err != nil {
-			return fmt.Errorf("failed to compare '%s': %w", relPath, err)
-		}
+	for i := 0; i < parallel; i++ {
+		g.Go(func() error {
+			for relPath := range jobs {
+				usedAlgorithm, sourceHash, targetHash, err := comparableHashes(gctx, sourceFiles[relPath], targetFiles[relPath], sourceClient, targetClient, algorithm)
+				if err != nil {
+					return fmt.Errorf("failed to compare '%s': %w", relPath, err)
+				}
+
+				mu.Lock()
+				if strings.EqualFold(sourceHash, targetHash) {
+					result.Identical = append(result.Identical, diffFile{
+						Path:      relPath,
+						Algorithm: usedAlgorithm,
+						Hash:      strings.ToLower(sourceHash),
+					})
+				} else {
+					result.Different = append(result.Different, diffMismatch{
+						Path:       relPath,
+						Algorithm:  usedAlgorithm,
+						SourceHash: strings.ToLower(sourceHash),
+						TargetHash: strings.ToLower(targetHash),
+					})
+				}
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
 
-		if strings.EqualFold(sourceHash, targetHash) {
-			result.Identical = append(result.Identical, diffFile{
-				Path:      relPath,
-				Algorithm: algorithm,
-				Hash:      strings.ToLower(sourceHash),
-			})
-		} else {
-			result.Different = append(result.Different, diffMismatch{
-				Path:       relPath,
-				Algorithm:  algorithm,
-				SourceHash: strings.ToLower(sourceHash),
-				TargetHash: strings.ToLower(targetHash),
-			})
-		}
+	if err := g.Wait(); err != nil {
+		return diffResult{}, err
 	}
 
 	for relPath := range targetFiles {
@@ -261,9 +460,15 @@ func runDiff(cmd *cobra.Command, _ []string) error {
 		return result.Different[i].Path < result.Different[j].Path
 	})
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	result.Summary = diffSummary{
+		Identical:  len(result.Identical),
+		OnlySource: len(result.OnlySource),
+		OnlyTarget: len(result.OnlyTarget),
+		Different:  len(result.Different),
+	}
+	result.Summary.Total = result.Summary.Identical + result.Summary.OnlySource + result.Summary.OnlyTarget + result.Summary.Different
+
+	return result, nil
 }
 
 func normalizeRepoPath(value string) string {
@@ -288,8 +493,8 @@ func relativeAssetPath(assetPath string, root string) string {
 	return assetPath
 }
 
-func collectRepoFiles(client *nexus.NexusClient, repository string, root string) (map[string]fileEntry, error) {
-	assets, err := client.GetFilesInDirectory(repository, root)
+func collectRepoFiles(ctx context.Context, client *nexus.NexusClient, repository string, root string) (map[string]fileEntry, error) {
+	assets, err := client.GetFilesInDirectory(ctx, repository, root)
 	if err != nil {
 		return nil, err
 	}
@@ -345,47 +550,42 @@ func collectLocalFiles(root string) (map[string]fileEntry, error) {
 	return files, err
 }
 
-func normalizeChecksumMap(input map[string]string) map[string]string {
-	output := make(map[string]string, len(input))
-	for key, value := range input {
-		if value == "" {
-			continue
-		}
-		output[strings.ToLower(key)] = strings.ToLower(value)
-	}
-	return output
-}
-
-func comparableHashes(source fileEntry, target fileEntry, sourceClient *nexus.NexusClient, targetClient *nexus.NexusClient) (string, string, string, error) {
+// comparableHashes returns an algorithm and the hashes of both entries for it,
+// preferring checksums reported by Nexus and computing missing ones from the
+// content. A non-empty forced algorithm is always used, even when both sides
+// share a different checksum.
+func comparableHashes(ctx context.Context, source fileEntry, target fileEntry, sourceClient *nexus.NexusClient, targetClient *nexus.NexusClient, forced string) (string, string, string, error) {
 	sourceHashes := map[string]string{}
 	if source.Asset != nil && source.Asset.Checksum != nil {
-		sourceHashes = normalizeChecksumMap(source.Asset.Checksum)
+		sourceHashes = nexus.NormalizeChecksums(source.Asset.Checksum)
 	}
 
 	targetHashes := map[string]string{}
 	if target.Asset != nil && target.Asset.Checksum != nil {
-		targetHashes = normalizeChecksumMap(target.Asset.Checksum)
+		targetHashes = nexus.NormalizeChecksums(target.Asset.Checksum)
+	} else if target.Checksums != nil {
+		targetHashes = nexus.NormalizeChecksums(target.Checksums)
 	}
 
-	for _, algorithm := range hashPreference {
-		if sourceHashes[algorithm] != "" && targetHashes[algorithm] != "" {
-			return algorithm, sourceHashes[algorithm], targetHashes[algorithm], nil
+	chosen := forced
+	if chosen == "" {
+		if algorithm, sourceHash, targetHash := nexus.CommonChecksum(sourceHashes, targetHashes); algorithm != "" {
+			return algorithm, sourceHash, targetHash, nil
 		}
-	}
 
-	chosen := ""
-	for _, algorithm := range hashPreference {
-		if sourceHashes[algorithm] != "" || targetHashes[algorithm] != "" {
-			chosen = algorithm
-			break
+		for _, algorithm := range nexus.ChecksumPreference {
+			if sourceHashes[algorithm] != "" || targetHashes[algorithm] != "" {
+				chosen = algorithm
+				break
+			}
 		}
 	}
 	if chosen == "" {
-		chosen = hashPreference[0]
+		chosen = nexus.ChecksumPreference[0]
 	}
 
 	if sourceHashes[chosen] == "" {
-		hashValue, err := computeHashForEntry(source, sourceClient, chosen)
+		hashValue, err := computeHashForEntry(ctx, source, sourceClient, chosen)
 		if err != nil {
 			return "", "", "", err
 		}
@@ -393,7 +593,7 @@ func comparableHashes(source fileEntry, target fileEntry, sourceClient *nexus.Ne
 	}
 
 	if targetHashes[chosen] == "" {
-		hashValue, err := computeHashForEntry(target, targetClient, chosen)
+		hashValue, err := computeHashForEntry(ctx, target, targetClient, chosen)
 		if err != nil {
 			return "", "", "", err
 		}
@@ -403,7 +603,7 @@ func comparableHashes(source fileEntry, target fileEntry, sourceClient *nexus.Ne
 	return chosen, sourceHashes[chosen], targetHashes[chosen], nil
 }
 
-func computeHashForEntry(entry fileEntry, client *nexus.NexusClient, algorithm string) (string, error) {
+func computeHashForEntry(ctx context.Context, entry fileEntry, client *nexus.NexusClient, algorithm string) (string, error) {
 	if entry.Asset != nil {
 		if entry.Asset.DownloadUrl == "" {
 			return "", fmt.Errorf("download URL missing for %s", entry.Asset.Path)
@@ -411,7 +611,11 @@ func computeHashForEntry(entry fileEntry, client *nexus.NexusClient, algorithm s
 		if client == nil {
 			return "", fmt.Errorf("nexus client is required to hash %s", entry.Asset.Path)
 		}
-		return client.ComputeHashFromDownloadURL(entry.Asset.DownloadUrl, algorithm)
+		return client.ComputeHashFromDownloadURL(ctx, entry.Asset.DownloadUrl, algorithm)
+	}
+	if entry.Checksums != nil {
+		// Archive entries cannot be read again, all supported hashes were computed up front
+		return "", fmt.Errorf("no %s checksum for archive entry %s", algorithm, entry.RelativePath)
 	}
 	if entry.LocalPath == "" {
 		return "", fmt.Errorf("local path is missing for %s", entry.RelativePath)
@@ -0,0 +1,134 @@
+package asset
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"nexus-util/nexus"
+)
+
+// collectArchiveFiles lists the regular files of a .tar, .tar.gz/.tgz or .zip
+// archive, detected by extension. Every entry is streamed once through all
+// supported hashers, so that it can be compared with whatever checksum the
+// other side reports. When root is set, only entries below it are collected,
+// relative to it, like a local directory compared with --path.
+func collectArchiveFiles(archivePath string, root string) (map[string]fileEntry, error) {
+	name := strings.ToLower(archivePath)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return collectZipFiles(archivePath, root)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return collectTarFiles(archivePath, root, true)
+	case strings.HasSuffix(name, ".tar"):
+		return collectTarFiles(archivePath, root, false)
+	default:
+		return nil, fmt.Errorf("unsupported archive '%s': must end in .tar, .tar.gz, .tgz or .zip", archivePath)
+	}
+}
+
+func collectTarFiles(archivePath string, root string, gzipped bool) (map[string]fileEntry, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if gzipped {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archivePath, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	files := make(map[string]fileEntry)
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archivePath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := addArchiveEntry(files, header.Name, root, tr); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func collectZipFiles(archivePath string, root string) (map[string]fileEntry, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	files := make(map[string]fileEntry)
+	for _, entry := range zr.File {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from %s: %w", entry.Name, archivePath, err)
+		}
+		err = addArchiveEntry(files, entry.Name, root, rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// addArchiveEntry hashes the content of an archive entry and adds it to files
+// when it lies below root
+func addArchiveEntry(files map[string]fileEntry, name string, root string, content io.Reader) error {
+	relPath := strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(name, "\\", "/")), "/")
+	if root != "" {
+		if !strings.HasPrefix(relPath, root+"/") {
+			return nil
+		}
+		relPath = strings.TrimPrefix(relPath, root+"/")
+	}
+
+	hashers := map[string]hash.Hash{}
+	writers := make([]io.Writer, 0, len(nexus.ChecksumPreference))
+	for _, algorithm := range nexus.ChecksumPreference {
+		hasher, err := newLocalHashForAlgorithm(algorithm)
+		if err != nil {
+			return err
+		}
+		hashers[algorithm] = hasher
+		writers = append(writers, hasher)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), content); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	checksums := make(map[string]string, len(hashers))
+	for algorithm, hasher := range hashers {
+		checksums[algorithm] = hex.EncodeToString(hasher.Sum(nil))
+	}
+	files[relPath] = fileEntry{
+		RelativePath: relPath,
+		Checksums:    checksums,
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var DuCmd = &cobra.Command{
+	Use:   "du [subdir]",
+	Short: "Report the disk usage of a directory in Nexus repository",
+	Long: `Add up the sizes of the files in a directory (or the whole repository) in
+Nexus OSS Raw Repository and print the total of every top-level subdirectory
+followed by the grand total, like du --max-depth=1. Sizes come from the search
+API, with a HEAD request for files it did not report; files whose size still
+cannot be determined are counted separately as unknown.
+
+Examples:
+  # Show the usage of every top-level directory of the repository
+  nexus-util asset du -a http://nexus.example.com -r myrepo -u user -p pass
+
+  # Show the usage below a subdirectory in KB/MB/GB
+  nexus-util asset du -a http://nexus.example.com -r myrepo -u user -p pass --human releases/`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDu,
+}
+
+func runDu(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get du-specific flags
+	human, _ := cmd.Flags().GetBool("human")
+
+	subdir := ""
+	if len(args) > 0 {
+		subdir = args[0]
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	return runDuWithClient(ctx, client, repository, subdir, human, os.Stdout)
+}
+
+// duUsage is the space used by the files below one directory
+type duUsage struct {
+	name    string
+	bytes   int64
+	files   int
+	unknown int
+}
+
+// add counts a file of the given size, or of unknown size when known is false
+func (u *duUsage) add(size int64, known bool) {
+	u.files++
+	if !known {
+		u.unknown++
+		return
+	}
+	u.bytes += size
+}
+
+// runDuWithClient sums the sizes of the files below subdir per top-level
+// subdirectory and writes one line per subdirectory and a total line to out
+func runDuWithClient(ctx context.Context, client *nexus.NexusClient, repository, subdir string, human bool, out io.Writer) error {
+	files, err := client.GetFilesInDirectory(ctx, repository, subdir)
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	prefix := strings.Trim(subdir, "/")
+	total := &duUsage{name: repository + "/"}
+	if prefix != "" {
+		total.name += prefix + "/"
+	}
+	dirs := map[string]*duUsage{}
+
+	for _, file := range files {
+		size, err := client.AssetSize(ctx, repository, file)
+		if err != nil {
+			client.Warnf("failed to get size of %s: %v", file.Path, err)
+		}
+		known := err == nil
+		total.add(size, known)
+
+		rel := strings.TrimPrefix(file.Path, "/")
+		if prefix != "" {
+			rel = strings.TrimPrefix(rel, prefix+"/")
+		}
+		top, _, nested := strings.Cut(rel, "/")
+		if !nested {
+			// Files directly in subdir only count towards the total
+			continue
+		}
+		usage, ok := dirs[top]
+		if !ok {
+			usage = &duUsage{name: total.name + top + "/"}
+			dirs[top] = usage
+		}
+		usage.add(size, known)
+	}
+
+	names := make([]string, 0, len(dirs))
+	for name := range dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]*duUsage, 0, len(names)+1)
+	for _, name := range names {
+		lines = append(lines, dirs[name])
+	}
+	lines = append(lines, total)
+	printDu(out, lines, human)
+	return nil
+}
+
+// printDu prints "size  path" lines with right aligned sizes, noting how many
+// files of each directory were left out because their size is unknown
+func printDu(out io.Writer, lines []*duUsage, human bool) {
+	sizes := make([]string, len(lines))
+	width := 1
+	for i, usage := range lines {
+		if human {
+			sizes[i] = nexus.FormatBytes(uint64(usage.bytes))
+		} else {
+			sizes[i] = strconv.FormatInt(usage.bytes, 10)
+		}
+		width = max(width, len(sizes[i]))
+	}
+
+	for i, usage := range lines {
+		fmt.Fprintf(out, "%*s  %s", width, sizes[i], usage.name)
+		if usage.unknown > 0 {
+			fmt.Fprintf(out, "  (%d of %d files of unknown size)", usage.unknown, usage.files)
+		}
+		fmt.Fprintln(out)
+	}
+}
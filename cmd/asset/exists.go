@@ -0,0 +1,137 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var ExistsCmd = &cobra.Command{
+	Use:   "exists [flags] <path>...",
+	Short: "Check that files exist in Nexus repository",
+	Long: `Check that files exist in Nexus OSS Raw Repository, e.g. to gate a CI job.
+Each path is reported as OK or MISSING. The command fails when any path is
+missing, or with --any only when none of them exist.
+
+Examples:
+  # Fail unless both files exist
+  nexus-util asset exists -a http://nexus.example.com -r myrepo -u user -p pass dir/app.tar.gz dir/app.sha256
+
+  # Succeed when at least one of the files exists
+  nexus-util asset exists -a http://nexus.example.com -r myrepo -u user -p pass --any dir/app-linux.tar.gz dir/app-linux.zip`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runExists,
+}
+
+func runExists(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get exists-specific flags
+	all, _ := cmd.Flags().GetBool("all")
+	anyMode, _ := cmd.Flags().GetBool("any")
+	anyMode = anyMode || !all
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	return runExistsWithClient(ctx, client, repository, args, anyMode, os.Stdout)
+}
+
+// runExistsWithClient reports whether each path exists and fails unless all of
+// them (or, with anyMode, at least one of them) do.
+func runExistsWithClient(ctx context.Context, client *nexus.NexusClient, repository string, paths []string, anyMode bool, out io.Writer) error {
+	present := 0
+	for _, path := range paths {
+		exists, err := client.FileExists(ctx, repository, path)
+		if err != nil {
+			return fmt.Errorf("failed to check '%s': %w", path, err)
+		}
+		status := "MISSING"
+		if exists {
+			status = "OK"
+			present++
+		}
+		fmt.Fprintf(out, "%-8s %s\n", status, path)
+	}
+
+	if anyMode {
+		if present == 0 {
+			return fmt.Errorf("none of the %d paths exist", len(paths))
+		}
+		return nil
+	}
+	if missing := len(paths) - present; missing > 0 {
+		return fmt.Errorf("%d of %d paths missing", missing, len(paths))
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var HeadCmd = &cobra.Command{
+	Use:   "head [flags] <path>",
+	Short: "Show the raw response headers Nexus returns for a file",
+	Long: `Send a HEAD request for a file in Nexus OSS Raw Repository and print the
+status line and every response header exactly as the server sent them. Unlike
+stat, nothing is interpreted, which helps to find out why checksums or the last
+modification time are missing. The command fails when the status is not 2xx.
+
+Examples:
+  # Show the headers of a file
+  nexus-util asset head -a http://nexus.example.com -r myrepo -u user -p pass dir/file.txt
+
+  # Also log the request and any retries
+  nexus-util asset head -a http://nexus.example.com -r myrepo -u user -p pass --log-level debug dir/file.txt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHead,
+}
+
+func runHead(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	return runHeadWithClient(ctx, client, repository, args[0], os.Stdout)
+}
+
+// runHeadWithClient prints the status line and the sorted response headers of
+// a HEAD request for filePath to out. It returns an error after printing them
+// when the status is not 2xx.
+func runHeadWithClient(ctx context.Context, client *nexus.NexusClient, repository, filePath string, out io.Writer) error {
+	resp, err := client.HeadAsset(ctx, repository, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get headers of '%s': %w", filePath, err)
+	}
+
+	fmt.Fprintf(out, "%s %s\n", resp.Proto, resp.Status)
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			fmt.Fprintf(out, "%s: %s\n", name, value)
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HEAD '%s' returned status %d", filePath, resp.StatusCode)
+	}
+	return nil
+}
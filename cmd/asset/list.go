@@ -1,7 +1,13 @@
 package asset
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
 
 	"nexus-util/config"
 	"nexus-util/nexus"
@@ -22,6 +28,27 @@ Examples:
   # List files in a specific subdirectory
   nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass subdir/
 
+  # List only archives anywhere under a subdirectory
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --pattern '*.tar.gz' subdir/
+
+  # List files matching a path pattern
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --pattern 'releases/**/binary'
+
+  # Show size and last modification time of each file, like ls -l
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --long subdir/
+
+  # Fill in sizes and times the search API did not report with a HEAD request per file
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --long --stat subdir/
+
+  # List files that were not modified in the last 90 days
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --long --older-than 90d subdir/
+
+  # Look at the first 100 files of a huge repository without enumerating all of it
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --max-results 100
+
+  # List files as JSON with sizes and checksums
+  nexus-util asset list -a http://nexus.example.com -r myrepo -u user -p pass --output json subdir/
+
   # List files with quiet mode (only file paths)
   nexus-util asset list -q -a http://nexus.example.com -r myrepo -u user -p pass subdir/`,
 	Args: cobra.MaximumNArgs(1),
@@ -29,16 +56,41 @@ Examples:
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get common flags
 	address, _ := cmd.Flags().GetString("address")
 	repository, _ := cmd.Flags().GetString("repository")
 	username, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	configPath, _ := cmd.Flags().GetString("config")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
 
+	// Get list-specific flags
+	pattern, _ := cmd.Flags().GetString("pattern")
+	output, _ := cmd.Flags().GetString("output")
+	long, _ := cmd.Flags().GetBool("long")
+	stat, _ := cmd.Flags().GetBool("stat")
+	maxResults, _ := cmd.Flags().GetInt("max-results")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	newerThan, _ := cmd.Flags().GetString("newer-than")
+
+	age, err := nexus.ParseAgeFilter(olderThan, newerThan, time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Get subdir argument (optional)
 	var subdir string
 	if len(args) > 0 {
@@ -50,6 +102,16 @@ func runList(cmd *cobra.Command, args []string) error {
 		"nexusAddress": address,
 		"user":         username,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
@@ -59,31 +121,152 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
 
 	// Create Nexus client
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	if maxResults < 0 {
+		return fmt.Errorf("--max-results must not be negative")
+	}
+	client.MaxResults = maxResults
+
+	opts := listOptions{Pattern: pattern, Format: output, Long: long || stat, Stat: stat, Age: age}
+	return runListWithClient(ctx, client, repository, subdir, opts, os.Stdout)
+}
+
+// listOptions controls which files are listed and how they are printed
+type listOptions struct {
+	// Pattern filters files with a glob pattern
+	Pattern string
+	// Format is "text" or "json"
+	Format string
+	// Long prints the size and last modification time before each path
+	Long bool
+	// Stat fetches size and last modification time with a HEAD request per file
+	// instead of relying on the search response
+	Stat bool
+	// Age keeps only files modified within its time range
+	Age nexus.AgeFilter
+}
+
+// listEntry is the JSON representation of a listed file
+type listEntry struct {
+	Path         string            `json:"path"`
+	Size         int64             `json:"size,omitempty"`
+	LastModified *time.Time        `json:"lastModified,omitempty"`
+	Checksum     map[string]string `json:"checksum,omitempty"`
+}
+
+// runListWithClient lists files with an already configured client and writes
+// them to out as plain paths (format "text") or a JSON array (format "json")
+func runListWithClient(ctx context.Context, client *nexus.NexusClient, repository, subdir string, opts listOptions, out io.Writer) error {
+	if opts.Format != "text" && opts.Format != "json" {
+		return fmt.Errorf("invalid output format '%s': must be text or json", opts.Format)
+	}
 
 	// Get files in directory
-	files, err := client.GetFilesInDirectory(repository, subdir)
+	files, err := client.GetFilesMatching(ctx, repository, subdir, opts.Pattern)
 	if err != nil {
 		return fmt.Errorf("failed to list files: %w", err)
 	}
+	files = client.FilterByAge(ctx, repository, files, opts.Age)
 
-	// Print files
-	if dryRun {
+	if client.DryRun {
 		client.Logf("Dry run: Would list %d files", len(files))
-	} else {
-		if !quiet {
-			if subdir == "" {
-				fmt.Printf("Files in repository root (%d files):\n", len(files))
-			} else {
-				fmt.Printf("Files in '%s' (%d files):\n", subdir, len(files))
-			}
-		}
+		return nil
+	}
+
+	if opts.Stat {
+		statFiles(ctx, client, repository, files)
+	}
+
+	// Print files
+	if opts.Format == "json" {
+		entries := make([]listEntry, 0, len(files))
 		for _, file := range files {
-			fmt.Println(file)
+			entries = append(entries, listEntry{Path: file.Path, Size: file.FileSize, LastModified: file.LastModified, Checksum: file.Checksum})
 		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if !client.Quiet {
+		if subdir == "" {
+			fmt.Fprintf(os.Stderr, "Files in repository root (%d files):\n", len(files))
+		} else {
+			fmt.Fprintf(os.Stderr, "Files in '%s' (%d files):\n", subdir, len(files))
+		}
+	}
+	if opts.Long {
+		printLongList(out, files)
+		return nil
+	}
+	for _, file := range files {
+		fmt.Fprintln(out, file.Path)
 	}
 
 	return nil
 }
+
+// statFiles replaces the size and last modification time of each file with the
+// values from a HEAD request. Files whose request fails keep their search values.
+func statFiles(ctx context.Context, client *nexus.NexusClient, repository string, files []nexus.Asset) {
+	for i := range files {
+		info, err := client.GetAssetInfo(ctx, repository, files[i].Path)
+		if err != nil {
+			client.Warnf("failed to stat %s: %v", files[i].Path, err)
+			continue
+		}
+		if info.Size >= 0 {
+			files[i].FileSize = info.Size
+		}
+		if info.LastModified != nil {
+			files[i].LastModified = info.LastModified
+		}
+	}
+}
+
+// printLongList prints files as "size  last-modified  path" lines with right
+// aligned sizes; unknown values are shown as "-"
+func printLongList(out io.Writer, files []nexus.Asset) {
+	sizes := make([]string, len(files))
+	width := 1
+	for i, file := range files {
+		sizes[i] = "-"
+		if file.FileSize > 0 {
+			sizes[i] = strconv.FormatInt(file.FileSize, 10)
+		}
+		width = max(width, len(sizes[i]))
+	}
+
+	for i, file := range files {
+		modified := "-"
+		if file.LastModified != nil {
+			modified = file.LastModified.Local().Format(listTimeFormat)
+		}
+		fmt.Fprintf(out, "%*s  %-*s  %s\n", width, sizes[i], len(listTimeFormat), modified, file.Path)
+	}
+}
+
+// listTimeFormat is the layout of modification times in long listings
+const listTimeFormat = "2006-01-02 15:04"
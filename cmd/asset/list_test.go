@@ -0,0 +1,736 @@
+package asset
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+// captureOutput runs fn with os.Stdout and os.Stderr redirected and returns what was written to each
+func captureOutput(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdoutW, stderrW
+	defer func() {
+		os.Stdout, os.Stderr = origStdout, origStderr
+	}()
+
+	var stdout, stderr bytes.Buffer
+	done := make(chan struct{}, 2)
+	go func() { _, _ = io.Copy(&stdout, stdoutR); done <- struct{}{} }()
+	go func() { _, _ = io.Copy(&stderr, stderrR); done <- struct{}{} }()
+
+	fn()
+
+	stdoutW.Close()
+	stderrW.Close()
+	<-done
+	<-done
+	return stdout.String(), stderr.String()
+}
+
+func TestListSeparatesResultsFromLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"path":"dir/a.txt"},{"path":"dir/b.txt"}],"continuationToken":null}`))
+	}))
+	defer server.Close()
+
+	root := &cobra.Command{Use: "nexus-util"}
+	for _, name := range []string{"address", "user", "password", "token", "client-cert", "client-key", "ca-bundle", "proxy", "timeout", "repository", "config"} {
+		root.PersistentFlags().String(name, "", "")
+	}
+	for _, name := range []string{"quiet", "dry", "insecure"} {
+		root.PersistentFlags().Bool(name, false, "")
+	}
+	ListCmd.Flags().String("pattern", "", "")
+	ListCmd.Flags().String("output", "text", "")
+	root.AddCommand(ListCmd)
+	root.SetArgs([]string{"list", "--address", server.URL, "--repository", "myrepo", "--config", "/non/existent.yaml", "dir/"})
+
+	var runErr error
+	stdout, stderr := captureOutput(t, func() {
+		runErr = root.ExecuteContext(context.Background())
+	})
+	if runErr != nil {
+		t.Fatalf("list failed: %v", runErr)
+	}
+
+	if stdout != "dir/a.txt\ndir/b.txt\n" {
+		t.Errorf("expected only file paths on stdout, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "Files in 'dir/' (2 files)") || !strings.Contains(stderr, "Found 2 files") {
+		t.Errorf("expected header and logs on stderr, got %q", stderr)
+	}
+}
+
+func TestRunListWithClientJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"path":"dir/a.jar","fileSize":12,"checksum":{"sha1":"abc"}},{"path":"dir/b.txt"}],"continuationToken":null}`))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	var out bytes.Buffer
+	if err := runListWithClient(context.Background(), client, "myrepo", "dir/", listOptions{Format: "json"}, &out); err != nil {
+		t.Fatalf("runListWithClient failed: %v", err)
+	}
+
+	var entries []listEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("expected JSON array, got %q: %v", out.String(), err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Path != "dir/a.jar" || entries[0].Size != 12 || entries[0].Checksum["sha1"] != "abc" {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Path != "dir/b.txt" || entries[1].Size != 0 || entries[1].Checksum != nil {
+		t.Errorf("unexpected second entry: %+v", entries[1])
+	}
+
+	out.Reset()
+	if err := runListWithClient(context.Background(), client, "myrepo", "dir/", listOptions{Pattern: "*.jar", Format: "text"}, &out); err != nil {
+		t.Fatalf("runListWithClient failed: %v", err)
+	}
+	if out.String() != "dir/a.jar\n" {
+		t.Errorf("unexpected text output %q", out.String())
+	}
+
+	if err := runListWithClient(context.Background(), client, "myrepo", "", listOptions{Format: "yaml"}, &out); err == nil {
+		t.Error("expected error for unknown output format")
+	}
+}
+
+func TestRunExistsWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD request, got %s", r.Method)
+		}
+		if !strings.HasSuffix(r.URL.Path, "/present.txt") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	tests := []struct {
+		name    string
+		paths   []string
+		anyMode bool
+		wantErr bool
+	}{
+		{"all present", []string{"dir/present.txt"}, false, false},
+		{"all with missing", []string{"dir/present.txt", "dir/missing.txt"}, false, true},
+		{"any with missing", []string{"dir/present.txt", "dir/missing.txt"}, true, false},
+		{"any none present", []string{"dir/missing.txt"}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runExistsWithClient(context.Background(), client, "myrepo", tt.paths, tt.anyMode, &out)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runExistsWithClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, path := range tt.paths {
+				want := "OK       " + path
+				if strings.Contains(path, "missing") {
+					want = "MISSING  " + path
+				}
+				if !strings.Contains(out.String(), want+"\n") {
+					t.Errorf("expected %q in report, got %q", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunHeadWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD request, got %s", r.Method)
+		}
+		if !strings.HasSuffix(r.URL.Path, "/present.txt") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Checksum-Sha1", "abc")
+		w.Header().Add("X-Multi", "one")
+		w.Header().Add("X-Multi", "two")
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	var out bytes.Buffer
+	if err := runHeadWithClient(context.Background(), client, "myrepo", "dir/present.txt", &out); err != nil {
+		t.Fatalf("runHeadWithClient failed: %v", err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if lines[0] != "HTTP/1.1 200 OK" {
+		t.Errorf("expected the status line first, got %q", lines[0])
+	}
+	for _, want := range []string{"Last-Modified: Mon, 02 Jan 2006 15:04:05 GMT\n", "X-Checksum-Sha1: abc\n", "X-Multi: one\nX-Multi: two\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output, got %q", want, out.String())
+		}
+	}
+	if strings.Index(out.String(), "Last-Modified") > strings.Index(out.String(), "X-Checksum-Sha1") {
+		t.Errorf("expected headers sorted by name, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := runHeadWithClient(context.Background(), client, "myrepo", "dir/missing.txt", &out); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if !strings.HasPrefix(out.String(), "HTTP/1.1 404 Not Found\n") {
+		t.Errorf("expected the status line of a missing file to be printed, got %q", out.String())
+	}
+}
+
+func TestRunListWithClientLong(t *testing.T) {
+	var heads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			atomic.AddInt32(&heads, 1)
+			w.Header().Set("Content-Length", "7")
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"path":"dir/a.jar","fileSize":1234,"lastModified":"2024-03-01T10:20:00.000+00:00"},{"path":"dir/b.txt"}],"continuationToken":null}`))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	format := func(value string) string {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed.Local().Format(listTimeFormat)
+	}
+
+	var out bytes.Buffer
+	if err := runListWithClient(context.Background(), client, "myrepo", "dir/", listOptions{Format: "text", Long: true}, &out); err != nil {
+		t.Fatalf("runListWithClient failed: %v", err)
+	}
+	want := "1234  " + format("2024-03-01T10:20:00Z") + "  dir/a.jar\n" +
+		"   -  -                 dir/b.txt\n"
+	if out.String() != want {
+		t.Errorf("unexpected long listing:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if heads != 0 {
+		t.Errorf("expected no HEAD requests without --stat, got %d", heads)
+	}
+
+	out.Reset()
+	if err := runListWithClient(context.Background(), client, "myrepo", "dir/", listOptions{Format: "text", Long: true, Stat: true}, &out); err != nil {
+		t.Fatalf("runListWithClient failed: %v", err)
+	}
+	stat := "7  " + format("2006-01-02T15:04:05Z") + "  "
+	if want := stat + "dir/a.jar\n" + stat + "dir/b.txt\n"; out.String() != want {
+		t.Errorf("unexpected stat listing:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if heads != 2 {
+		t.Errorf("expected one HEAD request per file with --stat, got %d", heads)
+	}
+}
+
+func TestConfirmDirectoryDeletes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"path":"dir/a.txt"},{"path":"dir/sub/b.txt"}],"continuationToken":null}`))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	tests := []struct {
+		name    string
+		paths   []string
+		answer  string
+		wantErr bool
+		prompt  bool
+	}{
+		{"confirmed", []string{"dir/"}, "y\n", false, true},
+		{"confirmed long answer", []string{"dir/"}, "YES\n", false, true},
+		{"declined", []string{"dir/"}, "n\n", true, true},
+		{"default is no", []string{"dir/"}, "\n", true, true},
+		{"no input", []string{"dir/"}, "", true, true},
+		{"files only", []string{"dir/a.txt"}, "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := confirmDirectoryDeletes(context.Background(), client, "myrepo", tt.paths, strings.NewReader(tt.answer), &out)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmDirectoryDeletes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := strings.Contains(out.String(), "About to delete 2 files under dir/"); got != tt.prompt {
+				t.Errorf("expected prompt %v, got output %q", tt.prompt, out.String())
+			}
+		})
+	}
+}
+
+func TestDeleteByAge(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items":[
+				{"path":"dir/old.txt","lastModified":"2024-01-01T00:00:00Z"},
+				{"path":"dir/new.txt","lastModified":"2024-05-30T00:00:00Z"},
+				{"path":"dir/undated.txt"}
+			]}`))
+		case http.MethodHead:
+			if r.URL.Path == "/repository/myrepo/single.txt" {
+				w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	age, err := nexus.ParseAgeFilter("90d", "", now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Declining deletes nothing
+	var out bytes.Buffer
+	if err := deleteByAge(context.Background(), client, "myrepo", []string{"dir/"}, age, true, strings.NewReader("n\n"), &out); err == nil {
+		t.Error("Expected error when the deletion is declined")
+	}
+	if !strings.Contains(out.String(), "About to delete 1 of 3 files under dir/") || len(deleted) != 0 {
+		t.Errorf("Expected prompt and no deletes, got %q and %v", out.String(), deleted)
+	}
+
+	// Only the old files are deleted; the undated one is kept
+	if err := deleteByAge(context.Background(), client, "myrepo", []string{"dir/", "single.txt"}, age, false, nil, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sort.Strings(deleted)
+	expected := []string{"/repository/myrepo/dir/old.txt", "/repository/myrepo/single.txt"}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("Expected deletes %v, got %v", expected, deleted)
+	}
+}
+
+func TestRunPruneWithClient(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	undated := false
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			items := `{"path":"builds/1.jar","lastModified":"2024-05-01T00:00:00Z"},
+				{"path":"builds/2.jar","lastModified":"2024-05-20T00:00:00Z"},
+				{"path":"builds/3.jar","lastModified":"2024-05-31T00:00:00Z"},
+				{"path":"builds/notes.txt","lastModified":"2024-01-01T00:00:00Z"}`
+			if undated {
+				items += `,{"path":"builds/4.jar"}`
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items":[` + items + `]}`))
+		case http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/repository/myrepo/"))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	tests := []struct {
+		name string
+		opts pruneOptions
+		want []string
+	}{
+		{"keep newest", pruneOptions{Keep: 1, Pattern: "*.jar"}, []string{"builds/2.jar", "builds/1.jar"}},
+		{"keep days", pruneOptions{KeepDays: 7, Pattern: "*.jar"}, []string{"builds/2.jar", "builds/1.jar"}},
+		{"either rule keeps", pruneOptions{Keep: 2, KeepDays: 7}, []string{"builds/1.jar", "builds/notes.txt"}},
+		{"nothing to prune", pruneOptions{Keep: 5}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted = nil
+			var out bytes.Buffer
+			if err := runPruneWithClient(context.Background(), client, "myrepo", "builds/", tt.opts, now, &out); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(deleted, tt.want) {
+				t.Errorf("Expected deletes %v, got %v", tt.want, deleted)
+			}
+			if got := strings.Fields(out.String()); len(got) != len(tt.want) {
+				t.Errorf("Expected pruned paths on stdout, got %q", out.String())
+			}
+		})
+	}
+
+	// A file without a modification time stops the prune before any delete
+	undated = true
+	deleted = nil
+	var out bytes.Buffer
+	err := runPruneWithClient(context.Background(), client, "myrepo", "builds/", pruneOptions{Keep: 1}, now, &out)
+	if err == nil || !strings.Contains(err.Error(), "builds/4.jar") {
+		t.Errorf("Expected error naming the undated file, got: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected no deletes, got %v", deleted)
+	}
+
+	if err := (pruneOptions{}).validate(); err == nil {
+		t.Error("Expected error for a policy that keeps nothing")
+	}
+}
+
+func TestRunTreeWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[
+			{"path":"releases/org/example/app/1.0/app.jar"},
+			{"path":"releases/org/example/app/1.0/app.pom"},
+			{"path":"releases/org/example/app/1.1/app.jar"},
+			{"path":"releases/README.txt"}
+		],"continuationToken":null}`))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	tests := []struct {
+		name string
+		opts treeOptions
+		want string
+	}{
+		{"full", treeOptions{}, `myrepo/releases/
+├── README.txt
+└── org/example/app/
+    ├── 1.0/
+    │   ├── app.jar
+    │   └── app.pom
+    └── 1.1/
+        └── app.jar
+
+5 directories, 4 files
+`},
+		{"depth", treeOptions{Depth: 1}, `myrepo/releases/
+├── README.txt
+└── org/example/app/
+
+3 directories, 1 files
+`},
+		{"dirs only", treeOptions{DirsOnly: true}, `myrepo/releases/
+└── org/example/app/
+    ├── 1.0/
+    └── 1.1/
+
+5 directories
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := runTreeWithClient(context.Background(), client, "myrepo", "releases/", tt.opts, &out); err != nil {
+				t.Fatalf("runTreeWithClient failed: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("unexpected tree:\n%s\nwant:\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDuWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && strings.HasSuffix(r.URL.Path, "/docs/guide.pdf"):
+			w.Header().Set("Content-Length", "2048")
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items":[
+				{"path":"releases/app/1.0/app.jar","fileSize":1048576},
+				{"path":"releases/app/1.1/app.jar","fileSize":524288},
+				{"path":"releases/docs/guide.pdf"},
+				{"path":"releases/docs/missing.txt"},
+				{"path":"releases/README.txt","fileSize":100}
+			],"continuationToken":null}`))
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	var out bytes.Buffer
+	if err := runDuWithClient(context.Background(), client, "myrepo", "releases/", false, &out); err != nil {
+		t.Fatalf("runDuWithClient failed: %v", err)
+	}
+	want := `1572864  myrepo/releases/app/
+   2048  myrepo/releases/docs/  (1 of 2 files of unknown size)
+1575012  myrepo/releases/  (1 of 5 files of unknown size)
+`
+	if out.String() != want {
+		t.Errorf("unexpected usage:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := runDuWithClient(context.Background(), client, "myrepo", "releases/", true, &out); err != nil {
+		t.Fatalf("runDuWithClient failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "1.5 MB  myrepo/releases/app/\n") {
+		t.Errorf("expected human readable sizes, got:\n%s", out.String())
+	}
+}
+
+func TestVerifyManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"a.txt": "alpha", "sub/b.txt": "tampered"} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checksum := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	manifest := &nexus.Manifest{Repository: "myrepo", Files: []nexus.ManifestEntry{
+		{Path: "dir/a.txt", File: "a.txt", SHA256: checksum("alpha")},
+		{Path: "dir/sub/b.txt", File: "sub/b.txt", SHA256: checksum("bravo")},
+		{Path: "dir/c.txt", File: "c.txt", SHA256: checksum("charlie")},
+		{Path: "dir/d.txt", File: "../d.txt", SHA256: checksum("delta")},
+	}}
+
+	var out bytes.Buffer
+	err := verifyManifest(manifest, dir, true, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 missing, 2 mismatched") {
+		t.Fatalf("expected verification to fail with 1 missing and 2 mismatched files, got %v", err)
+	}
+	want := "MISMATCH  sub/b.txt\nMISSING   c.txt\nINVALID   ../d.txt\n"
+	if out.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	manifest.Files = manifest.Files[:1]
+	out.Reset()
+	if err := verifyManifest(manifest, dir, true, &out); err != nil {
+		t.Errorf("expected unchanged files to verify, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no output for verified files, got %q", out.String())
+	}
+}
+
+func TestComparableHashesForcedAlgorithm(t *testing.T) {
+	content := []byte("payload")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	localPath := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(localPath, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sha1Sum := sha1.Sum(content)
+	source := fileEntry{RelativePath: "file.txt", Asset: &nexus.Asset{
+		Path:        "file.txt",
+		DownloadUrl: server.URL + "/repository/repo/file.txt",
+		Checksum:    map[string]string{"sha1": hex.EncodeToString(sha1Sum[:])},
+	}}
+	target := fileEntry{RelativePath: "file.txt", LocalPath: localPath}
+
+	algorithm, sourceHash, targetHash, err := comparableHashes(context.Background(), source, target, client, nil, "")
+	if err != nil {
+		t.Fatalf("comparableHashes failed: %v", err)
+	}
+	if algorithm != "sha1" || sourceHash != targetHash {
+		t.Errorf("expected matching sha1 hashes without a forced algorithm, got %s %s %s", algorithm, sourceHash, targetHash)
+	}
+
+	algorithm, sourceHash, targetHash, err = comparableHashes(context.Background(), source, target, client, nil, "sha256")
+	if err != nil {
+		t.Fatalf("comparableHashes failed: %v", err)
+	}
+	sha256Sum := sha256.Sum256(content)
+	if algorithm != "sha256" || sourceHash != hex.EncodeToString(sha256Sum[:]) || targetHash != sourceHash {
+		t.Errorf("expected the forced sha256 to be computed for both sides, got %s %s %s", algorithm, sourceHash, targetHash)
+	}
+}
+
+func TestCompareFilesParallel(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			peak := atomic.LoadInt32(&maxInFlight)
+			if n <= peak || atomic.CompareAndSwapInt32(&maxInFlight, peak, n) {
+				break
+			}
+		}
+		// Give the other workers a chance to start their downloads
+		deadline := time.Now().Add(2 * time.Second)
+		for atomic.LoadInt32(&maxInFlight) < 2 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/repository/repo/")))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	dir := t.TempDir()
+	sourceFiles := map[string]fileEntry{}
+	targetFiles := map[string]fileEntry{}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		sourceFiles[name] = fileEntry{RelativePath: name, Asset: &nexus.Asset{Path: name, DownloadUrl: server.URL + "/repository/repo/" + name}}
+		content := name
+		if name == "c.txt" {
+			content = "changed"
+		}
+		if name == "d.txt" {
+			continue
+		}
+		localPath := filepath.Join(dir, name)
+		if err := os.WriteFile(localPath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		targetFiles[name] = fileEntry{RelativePath: name, LocalPath: localPath}
+	}
+	targetFiles["e.txt"] = fileEntry{RelativePath: "e.txt", LocalPath: filepath.Join(dir, "e.txt")}
+
+	result, err := compareFiles(context.Background(), sourceFiles, targetFiles, client, nil, "", 4)
+	if err != nil {
+		t.Fatalf("compareFiles failed: %v", err)
+	}
+	if len(result.Identical) != 2 || result.Identical[0].Path != "a.txt" || result.Identical[1].Path != "b.txt" {
+		t.Errorf("unexpected identical files: %+v", result.Identical)
+	}
+	if len(result.Different) != 1 || result.Different[0].Path != "c.txt" {
+		t.Errorf("unexpected different files: %+v", result.Different)
+	}
+	if !reflect.DeepEqual(result.OnlySource, []string{"d.txt"}) || !reflect.DeepEqual(result.OnlyTarget, []string{"e.txt"}) {
+		t.Errorf("unexpected one-sided files: %v %v", result.OnlySource, result.OnlyTarget)
+	}
+	if want := (diffSummary{Identical: 2, OnlySource: 1, OnlyTarget: 1, Different: 1, Total: 5}); result.Summary != want {
+		t.Errorf("expected summary %+v, got %+v", want, result.Summary)
+	}
+	if peak := atomic.LoadInt32(&maxInFlight); peak < 2 {
+		t.Errorf("expected files to be hashed in parallel, at most %d downloads ran at once", peak)
+	}
+}
+
+func TestCollectArchiveFiles(t *testing.T) {
+	dir := t.TempDir()
+	entries := map[string]string{"app/bin/tool": "binary", "app/README": "readme", "other.txt": "other"}
+
+	tarPath := filepath.Join(dir, "bundle.tar.gz")
+	tarFile, err := os.Create(tarPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(tarFile)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "app/", Typeflag: tar.TypeDir, Mode: 0o755})
+	for name, content := range entries {
+		_ = tw.WriteHeader(&tar.Header{Name: "./" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))})
+		_, _ = tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	tarFile.Close()
+
+	zipPath := filepath.Join(dir, "bundle.zip")
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zipFile)
+	_, _ = zw.Create("app/")
+	for name, content := range entries {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	zw.Close()
+	zipFile.Close()
+
+	for _, archivePath := range []string{tarPath, zipPath} {
+		files, err := collectArchiveFiles(archivePath, "app")
+		if err != nil {
+			t.Fatalf("collectArchiveFiles(%s) failed: %v", archivePath, err)
+		}
+		if len(files) != 2 {
+			t.Fatalf("expected the 2 files below app/ in %s, got %v", archivePath, files)
+		}
+		sum := sha256.Sum256([]byte("binary"))
+		if got := files["bin/tool"].Checksums["sha256"]; got != hex.EncodeToString(sum[:]) {
+			t.Errorf("wrong sha256 for bin/tool in %s: %s", archivePath, got)
+		}
+		if files["README"].Checksums["md5"] == "" || files["README"].Checksums["sha1"] == "" {
+			t.Errorf("expected all checksums for README in %s, got %v", archivePath, files["README"].Checksums)
+		}
+	}
+
+	if _, err := collectArchiveFiles(filepath.Join(dir, "bundle.rar"), ""); err == nil {
+		t.Error("expected an error for an unsupported archive type")
+	}
+}
@@ -0,0 +1,129 @@
+package asset
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var MoveCmd = &cobra.Command{
+	Use:     "mv [flags] <src> <dst>",
+	Aliases: []string{"move"},
+	Short:   "Move or rename a file within Nexus repository",
+	Long: `Move or rename a file within Nexus OSS Raw Repository.
+The file is downloaded, uploaded to the new path and then deleted from the old path.
+If the upload fails, the original file is left untouched. An existing destination
+file is not replaced unless --overwrite is passed.
+
+Examples:
+  # Rename a file
+  nexus-util asset mv -a http://nexus.example.com -r myrepo -u user -p pass dir/old.txt dir/new.txt
+
+  # Move a file to another directory
+  nexus-util asset mv -a http://nexus.example.com -r myrepo -u user -p pass dir/file.txt archive/file.txt
+
+  # Dry run to see the planned operations
+  nexus-util asset mv --dry -a http://nexus.example.com -r myrepo -u user -p pass dir/old.txt dir/new.txt`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMove,
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	srcPath := args[0]
+	dstPath := args[1]
+
+	// Only single files can be moved
+	for _, path := range args {
+		if strings.HasSuffix(path, "/") || strings.HasSuffix(path, "\\") {
+			return fmt.Errorf("path '%s' is a directory, only files can be moved", path)
+		}
+	}
+	if nexus.NormalizeRemotePath(srcPath) == nexus.NormalizeRemotePath(dstPath) {
+		return fmt.Errorf("source and destination are the same: '%s'", srcPath)
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	if err := client.MoveFile(ctx, repository, srcPath, dstPath, overwrite); err != nil {
+		return fmt.Errorf("failed to move file: %w", err)
+	}
+
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "Success!")
+	}
+
+	return nil
+}
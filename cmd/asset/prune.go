@@ -0,0 +1,197 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var PruneCmd = &cobra.Command{
+	Use:   "prune [flags] <dir>",
+	Short: "Delete all but the newest files of a directory in Nexus repository",
+	Long: `Apply a retention policy to a directory in Nexus OSS Raw Repository: the
+matching files are sorted by last modification time, the newest --keep files
+and the files modified within the last --keep-days days are kept, and the rest
+is deleted. With both flags a file is kept when either rule keeps it.
+
+Every file must have a modification time, from the search response or a HEAD
+request; if any file cannot be dated nothing is deleted. The pruned paths are
+printed to stdout.
+
+Examples:
+  # Keep the 5 newest builds
+  nexus-util asset prune -a http://nexus.example.com -r myrepo -u user -p pass --keep 5 builds/
+
+  # Keep the jars of the last 30 days, previewing the deletions first
+  nexus-util asset prune --dry -a http://nexus.example.com -r myrepo -u user -p pass --keep-days 30 --pattern '*.jar' builds/`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPrune,
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get prune-specific flags
+	keep, _ := cmd.Flags().GetInt("keep")
+	keepDays, _ := cmd.Flags().GetInt("keep-days")
+	pattern, _ := cmd.Flags().GetString("pattern")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+	opts := pruneOptions{Keep: keep, KeepDays: keepDays, Pattern: pattern}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	client.ContinueOnError = continueOnError
+
+	return runPruneWithClient(ctx, client, repository, args[0], opts, time.Now(), os.Stdout)
+}
+
+// pruneOptions is the retention policy of a prune
+type pruneOptions struct {
+	// Keep is the number of newest files to keep
+	Keep int
+	// KeepDays keeps the files modified within this many days
+	KeepDays int
+	// Pattern limits the pruned files to those matching a glob pattern
+	Pattern string
+}
+
+// validate checks that the policy keeps something
+func (o pruneOptions) validate() error {
+	if o.Keep < 0 || o.KeepDays < 0 {
+		return fmt.Errorf("--keep and --keep-days must not be negative")
+	}
+	if o.Keep == 0 && o.KeepDays == 0 {
+		return fmt.Errorf("--keep or --keep-days is required")
+	}
+	return nil
+}
+
+// prunedFile is a file with its known modification time
+type prunedFile struct {
+	path     string
+	modified time.Time
+}
+
+// runPruneWithClient deletes the files below dir that the retention policy
+// does not keep and writes their paths to out. Nothing is deleted unless every
+// file has a modification time.
+func runPruneWithClient(ctx context.Context, client *nexus.NexusClient, repository, dir string, opts pruneOptions, now time.Time, out io.Writer) error {
+	files, err := client.GetFilesMatching(ctx, repository, dir, opts.Pattern)
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	dated := make([]prunedFile, 0, len(files))
+	for _, file := range files {
+		modified, err := client.ModificationTime(ctx, repository, file)
+		if err != nil {
+			return fmt.Errorf("cannot date '%s', nothing deleted: %w", file.Path, err)
+		}
+		dated = append(dated, prunedFile{path: file.Path, modified: modified})
+	}
+
+	// Newest first; ties are broken by path so the result is stable
+	sort.Slice(dated, func(i, j int) bool {
+		if !dated[i].modified.Equal(dated[j].modified) {
+			return dated[i].modified.After(dated[j].modified)
+		}
+		return dated[i].path < dated[j].path
+	})
+
+	var cutoff time.Time
+	if opts.KeepDays > 0 {
+		cutoff = now.AddDate(0, 0, -opts.KeepDays)
+	}
+	var pruned []string
+	for i, file := range dated {
+		if i < opts.Keep || (!cutoff.IsZero() && file.modified.After(cutoff)) {
+			continue
+		}
+		pruned = append(pruned, file.path)
+	}
+
+	client.Logf("Keeping %d of %d files, deleting %d", len(dated)-len(pruned), len(dated), len(pruned))
+	if len(pruned) == 0 {
+		return nil
+	}
+	for _, path := range pruned {
+		fmt.Fprintln(out, path)
+	}
+	return client.DeleteFiles(ctx, repository, pruned)
+}
@@ -0,0 +1,136 @@
+package asset
+
+import (
+	"fmt"
+	"os"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var PublishCmd = &cobra.Command{
+	Use:   "publish [flags] <localdir>",
+	Short: "Upload a directory to Nexus repository all at once",
+	Long: `Upload a local directory so that consumers never see it half uploaded.
+The files are first uploaded to a temporary directory next to the destination
+and only moved to the destination once every upload succeeded. If an upload
+fails, the temporary directory is removed and the destination is left untouched.
+
+An existing destination is only replaced with --overwrite; files of the old
+content that are not part of the new one are then removed.
+
+Examples:
+  # Publish a release
+  nexus-util asset publish -a http://nexus.example.com -r myrepo -u user -p pass --dest releases/v1 ./dist/
+
+  # Replace an already published snapshot
+  nexus-util asset publish -a http://nexus.example.com -r myrepo -u user -p pass --dest snapshots/latest --overwrite ./dist/
+
+  # Dry run to see what would be uploaded and moved
+  nexus-util asset publish --dry -a http://nexus.example.com -r myrepo -u user -p pass --dest releases/v1 ./dist/`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPublish,
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get publish-specific flags
+	dest, _ := cmd.Flags().GetString("dest")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	followSymlinks, _ := cmd.Flags().GetBool("follow-symlinks")
+
+	localDir := args[0]
+	if info, err := os.Stat(localDir); err != nil {
+		return fmt.Errorf("path '%s' doesn't exist", localDir)
+	} else if !info.IsDir() {
+		return fmt.Errorf("path '%s' is not a directory", localDir)
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	client.SetConcurrency(concurrency)
+
+	opts := nexus.PublishOptions{
+		Overwrite: overwrite,
+		Upload:    nexus.UploadOptions{Include: include, Exclude: exclude, FollowSymlinks: followSymlinks},
+	}
+	count, err := client.Publish(ctx, repository, localDir, dest, opts)
+	if err != nil {
+		return fmt.Errorf("failed to publish '%s': %w", localDir, err)
+	}
+
+	if !quiet && !dryRun {
+		fmt.Fprintf(os.Stderr, "Published %d files to '%s'\n", count, dest)
+	}
+	return nil
+}
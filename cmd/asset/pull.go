@@ -25,24 +25,72 @@ Examples:
   # Download a directory
   nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads dir/
 
+  # Show how many files and bytes a directory download would fetch
+  nexus-util asset pull --dry -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads dir/
+
+  # Download a directory using 8 parallel downloads
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --concurrency 8 dir/
+
+  # Download a directory and verify files against Nexus checksums
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --verify dir/
+
+  # Download a directory pushed with --preserve-empty-dirs, recreating its
+  # empty directories instead of downloading the .keep files
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads -s --strip-placeholders dir/
+
+  # Resume an interrupted download of a large file
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --resume big.iso
+
+  # Download a directory without using more than 10 MB/s in total
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --concurrency 4 --max-rate 10MB dir/
+
   # Download with custom root path
   nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --root custom/path file.txt
   
   # Save directory structure
   nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --saveStructure dir/subdir1/subdir2/
+
+  # Download a single file to ./downloads/dir/subdir/file.txt
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --saveStructure dir/subdir/file.txt
   
   # Exclude directory from downloading
-  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads dir/ --exclude dir/tmp`,
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads dir/ --exclude dir/tmp
+
+  # Record the sha256 of every downloaded file in a JSON manifest
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --manifest manifest.json dir/
+
+  # Write a manifest that can be checked later with 'sha256sum -c'
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --manifest SHA256SUMS --manifest-format sha256sum dir/
+
+  # Reuse files downloaded by earlier runs when their checksum is unchanged
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --cache-dir ~/.cache/nexus-util dir/
+
+  # Create the destination directory if it does not exist yet
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads/release-1.2 --mkdir dir/
+
+  # Exit with code 2 in scripts when the directory turns out to be empty
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --fail-empty dir/`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runPull,
 }
 
 func runPull(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get common flags
 	address, _ := cmd.Flags().GetString("address")
 	repository, _ := cmd.Flags().GetString("repository")
 	username, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	configPath, _ := cmd.Flags().GetString("config")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
@@ -53,6 +101,32 @@ func runPull(cmd *cobra.Command, args []string) error {
 	root, _ := cmd.Flags().GetString("root")
 	saveStructure, _ := cmd.Flags().GetBool("saveStructure")
 	excludeDirs, _ := cmd.Flags().GetStringSlice("exclude")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	progress, _ := cmd.Flags().GetBool("progress")
+	progressFormat, _ := cmd.Flags().GetString("progress-format")
+	verify, _ := cmd.Flags().GetBool("verify")
+	resume, _ := cmd.Flags().GetBool("resume")
+	maxRate, _ := cmd.Flags().GetString("max-rate")
+	skipSpaceCheck, _ := cmd.Flags().GetBool("skip-space-check")
+	failEmpty, _ := cmd.Flags().GetBool("fail-empty")
+	mkdir, _ := cmd.Flags().GetBool("mkdir")
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+	manifestFormat, _ := cmd.Flags().GetString("manifest-format")
+	cacheDir, _ := cmd.Flags().GetString("cache-dir")
+	stripPlaceholders, _ := cmd.Flags().GetBool("strip-placeholders")
+	placeholder, _ := cmd.Flags().GetString("placeholder")
+
+	switch manifestFormat {
+	case nexus.ManifestFormatJSON, nexus.ManifestFormatSHA256Sum:
+	default:
+		return fmt.Errorf("invalid manifest format '%s': must be %s or %s", manifestFormat, nexus.ManifestFormatJSON, nexus.ManifestFormatSHA256Sum)
+	}
+	if err := checkPlaceholderName(placeholder); err != nil {
+		return err
+	}
+	if err := nexus.ValidateProgressFormat(progressFormat); err != nil {
+		return err
+	}
 
 	// Validate and clean exclude directories
 	var cleanedExcludeDirs []string
@@ -69,6 +143,16 @@ func runPull(cmd *cobra.Command, args []string) error {
 		"nexusAddress": address,
 		"user":         username,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
@@ -78,16 +162,36 @@ func runPull(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Apply the defaults configured for the repository to flags that were not given
+	if repoRoot := cfg.GetRepoDefaults(repository).Root; !cmd.Flags().Changed("root") && repoRoot != "" {
+		root = repoRoot
+	}
 
 	// Validate destination directory
 	if destination == "" {
 		destination = "."
 	}
 
-	if _, err := os.Stat(destination); os.IsNotExist(err) {
-		return fmt.Errorf("destination path '%s' doesn't exist", destination)
-	}
-	if info, err := os.Stat(destination); err != nil || !info.IsDir() {
+	info, err := os.Stat(destination)
+	switch {
+	case os.IsNotExist(err) && !mkdir:
+		return fmt.Errorf("destination path '%s' doesn't exist (use --mkdir to create it)", destination)
+	case os.IsNotExist(err) && dryRun:
+		fmt.Fprintf(os.Stderr, "Dry run: Would create destination directory '%s'\n", destination)
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(destination, 0o755); err != nil {
+			return fmt.Errorf("failed to create destination directory '%s': %w", destination, err)
+		}
+		// Make sure the created path is usable before downloading into it
+		if info, err := os.Stat(destination); err != nil || !info.IsDir() {
+			return fmt.Errorf("destination path '%s' is not a directory", destination)
+		}
+	case err != nil || !info.IsDir():
 		return fmt.Errorf("destination path '%s' is not a directory", destination)
 	}
 
@@ -96,9 +200,51 @@ func runPull(cmd *cobra.Command, args []string) error {
 	destination = strings.TrimSuffix(destination, "\\")
 
 	// Create Nexus client
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	client.SetConcurrency(concurrency)
+	if progressFormat == nexus.ProgressFormatJSON {
+		client.Events = nexus.NewEventEmitter(os.Stderr)
+	} else if progress {
+		client.Progress = nexus.NewTextProgress(os.Stderr)
+	}
+	client.Verify = verify
+	client.Resume = resume
+	client.SkipSpaceCheck = skipSpaceCheck
+	if stripPlaceholders {
+		client.StripPlaceholder = placeholder
+	}
+	rate, err := nexus.ParseRate(maxRate)
+	if err != nil {
+		return err
+	}
+	client.RateLimit = nexus.NewRateLimiter(rate)
+	if manifestPath != "" {
+		client.Manifest = nexus.NewManifest(repository, cfg.GetNexusAddress(), destination)
+	}
+	if cacheDir != "" && !dryRun {
+		if client.Cache, err = nexus.NewDownloadCache(cacheDir); err != nil {
+			return err
+		}
+	}
 
 	// Process each source
+	processed := 0
 	for _, source := range args {
 		client.Logf("Process source '%s'", source)
 
@@ -108,20 +254,39 @@ func runPull(cmd *cobra.Command, args []string) error {
 		if isDir {
 			// Download directory
 			client.Logf("source '%s' is directory", source)
-			if err := client.DownloadDirectoryWithPath(repository, source, destination, root, saveStructure, cleanedExcludeDirs); err != nil {
+			count, err := client.DownloadDirectoryWithCount(ctx, repository, source, destination, root, saveStructure, cleanedExcludeDirs)
+			if err != nil {
 				return fmt.Errorf("failed to download directory: %w", err)
 			}
+			processed += count
 		} else {
 			// Download file
 			client.Logf("source '%s' is file", source)
-			if err := client.DownloadFileWithPath(repository, source, destination, root); err != nil {
+			if err := client.DownloadFileWithPath(ctx, repository, source, destination, root, saveStructure); err != nil {
 				return fmt.Errorf("failed to download file: %w", err)
 			}
+			processed++
+		}
+	}
+
+	// Write the manifest only once every download has succeeded
+	if manifestPath != "" {
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "Dry run: Would write manifest '%s'\n", manifestPath)
+		} else {
+			if err := client.Manifest.WriteFile(manifestPath, manifestFormat); err != nil {
+				return err
+			}
+			client.Logf("Manifest with %d files written to '%s'", client.Manifest.Len(), manifestPath)
 		}
 	}
 
+	if failEmpty && processed == 0 {
+		return fmt.Errorf("%w: nothing to download", nexus.ErrNoFiles)
+	}
+
 	if !quiet {
-		fmt.Println("Success!")
+		fmt.Fprintln(os.Stderr, "Success!")
 	}
 
 	return nil
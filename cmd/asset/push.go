@@ -1,6 +1,7 @@
 package asset
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +18,8 @@ var PushCmd = &cobra.Command{
 	Short: "Upload files or directories to Nexus repository",
 	Long: `Upload files or directories to Nexus OSS Raw Repository.
 This command combines the functionality of the original nexus_push.py script.
+Symbolic links inside uploaded directories are skipped unless --follow-symlinks
+is given.
 
 Examples:
   # Upload a single file
@@ -25,9 +28,48 @@ Examples:
   # Upload a directory
   nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass ./localdir/
 
+  # Upload a directory using 8 parallel uploads
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --concurrency 8 ./localdir/
+
+  # Upload only jars from a directory, skipping test trees
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --include '*.jar' --exclude '**/test/**' ./localdir/
+
+  # Upload a directory including the content its symbolic links point to
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --follow-symlinks ./localdir/
+
+  # Keep empty directories by uploading a .keep file into each of them
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --preserve-empty-dirs ./localdir/
+
   # Upload with custom destination path
   nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass -d custom/path file.txt
 
+  # Upload with an explicit Content-Type instead of detecting it from the extension
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --content-type text/plain build.log
+
+  # Upload a directory without replacing files that already exist in the repository
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --no-clobber ./localdir/
+
+  # Only upload files modified locally since they were last published
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --if-newer ./localdir/
+
+  # Send extra request headers, e.g. a label required by a fronting proxy
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --header 'X-Artifact-Label: nightly' ./localdir/
+
+  # Upload through the components API for servers that reject direct PUTs
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --multipart ./localdir/
+
+  # Check that Nexus recorded the same checksum as the uploaded content
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --verify ./localdir/
+
+  # Check afterwards that every uploaded file is listed in the repository
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --verify-count ./localdir/
+
+  # Exit with code 2 when the directory is empty or every file is filtered out
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --include '*.jar' --fail-empty ./localdir/
+
+  # Keep uploading after a failed file and report all failures at the end
+  nexus-util asset push -a http://nexus.example.com -r myrepo -u user -p pass --continue-on-error ./localdir/
+
   # Dry run to see what would be uploaded
   nexus-util asset push --dry -a http://nexus.example.com -r myrepo -u user -p pass file.txt`,
 	Args: cobra.MinimumNArgs(1),
@@ -35,11 +77,22 @@ Examples:
 }
 
 func runPush(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get common flags
 	address, _ := cmd.Flags().GetString("address")
 	repository, _ := cmd.Flags().GetString("repository")
 	username, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	configPath, _ := cmd.Flags().GetString("config")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
@@ -48,12 +101,55 @@ func runPush(cmd *cobra.Command, args []string) error {
 	// Get push-specific flags
 	destination, _ := cmd.Flags().GetString("destination")
 	relative, _ := cmd.Flags().GetBool("relative")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	progress, _ := cmd.Flags().GetBool("progress")
+	progressFormat, _ := cmd.Flags().GetString("progress-format")
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+	contentType, _ := cmd.Flags().GetString("content-type")
+	noClobber, _ := cmd.Flags().GetBool("no-clobber")
+	multipart, _ := cmd.Flags().GetBool("multipart")
+	ifNewer, _ := cmd.Flags().GetBool("if-newer")
+	maxRate, _ := cmd.Flags().GetString("max-rate")
+	verify, _ := cmd.Flags().GetBool("verify")
+	verifyCount, _ := cmd.Flags().GetBool("verify-count")
+	failEmpty, _ := cmd.Flags().GetBool("fail-empty")
+	headerSpecs, _ := cmd.Flags().GetStringArray("header")
+	followSymlinks, _ := cmd.Flags().GetBool("follow-symlinks")
+	preserveEmptyDirs, _ := cmd.Flags().GetBool("preserve-empty-dirs")
+	placeholder, _ := cmd.Flags().GetString("placeholder")
+
+	if err := checkPlaceholderName(placeholder); err != nil {
+		return err
+	}
+	if err := nexus.ValidateProgressFormat(progressFormat); err != nil {
+		return err
+	}
+	if !preserveEmptyDirs {
+		placeholder = ""
+	}
+
+	headers, err := nexus.ParseHeaders(headerSpecs)
+	if err != nil {
+		return err
+	}
 
 	// Load configuration
 	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
 		"nexusAddress": address,
 		"user":         username,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
@@ -63,11 +159,60 @@ func runPush(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Apply the defaults configured for the repository to flags that were not given
+	repoDefaults := cfg.GetRepoDefaults(repository)
+	if !cmd.Flags().Changed("destination") && repoDefaults.Destination != "" {
+		destination = repoDefaults.Destination
+	}
+	if !cmd.Flags().Changed("relative") && repoDefaults.Relative {
+		relative = true
+	}
+	destination = nexus.NormalizeRemotePath(destination)
 
 	// Create Nexus client
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	client.SetConcurrency(concurrency)
+	client.ContinueOnError = continueOnError
+	client.ContentType = contentType
+	client.NoClobber = noClobber
+	client.Multipart = multipart
+	client.IfNewer = ifNewer
+	client.Verify = verify
+	rate, err := nexus.ParseRate(maxRate)
+	if err != nil {
+		return err
+	}
+	client.RateLimit = nexus.NewRateLimiter(rate)
+	if progressFormat == nexus.ProgressFormatJSON {
+		client.Events = nexus.NewEventEmitter(os.Stderr)
+	} else if progress {
+		client.Progress = nexus.NewTextProgress(os.Stderr)
+	}
 
-	// Process each path
+	// Process each path; with --continue-on-error failures are reported at the end
+	var errs []error
+	processed := 0
 	for _, path := range args {
 		client.Logf("Process path '%s'", path)
 
@@ -85,39 +230,66 @@ func runPush(cmd *cobra.Command, args []string) error {
 		if info.IsDir() {
 			// Upload directory
 			client.Logf("path '%s' is directory", path)
-			if err := client.UploadDirectory(repository, path, relative, destination); err != nil {
-				return fmt.Errorf("failed to upload directory: %w", err)
+			opts := nexus.UploadOptions{Include: include, Exclude: exclude, VerifyCount: verifyCount, Headers: headers, FollowSymlinks: followSymlinks, EmptyDirPlaceholder: placeholder}
+			summary, err := client.UploadDirectoryWithSummary(ctx, repository, path, relative, destination, opts)
+			processed += summary.Uploaded
+			if !quiet && !dryRun {
+				if verifyCount {
+					fmt.Fprintf(os.Stderr, "Processed %d files from '%s', %d confirmed in repository\n", summary.Attempted, path, summary.Confirmed)
+				} else {
+					fmt.Fprintf(os.Stderr, "Processed %d files from '%s'\n", summary.Attempted, path)
+				}
+			}
+			if err != nil {
+				if !continueOnError {
+					return fmt.Errorf("failed to upload directory: %w", err)
+				}
+				errs = append(errs, fmt.Errorf("failed to upload directory '%s': %w", path, err))
 			}
 		} else {
 			// Upload file
 			client.Logf("path '%s' is file", path)
 			var destPath string
 			if relative {
-				destPath = filepath.Base(path)
+				destPath = nexus.JoinRemotePath(destination, filepath.Base(path))
 			} else {
-				destPath = path
-			}
-			if destination != "" {
-				destPath = filepath.Join(destination, destPath)
+				destPath = nexus.JoinRemotePath(destination, path)
 			}
-			// Convert to forward slashes for URL
-			destPath = strings.ReplaceAll(destPath, "\\", "/")
 
-			if err := client.UploadFile(repository, path, destPath); err != nil {
-				return fmt.Errorf("failed to upload file: %w", err)
+			uploaded, err := client.UploadFileWithResult(ctx, repository, path, destPath, headers)
+			if err != nil {
+				if !continueOnError {
+					return fmt.Errorf("failed to upload file: %w", err)
+				}
+				client.Errorf("Failed to upload '%s': %v", path, err)
+				errs = append(errs, fmt.Errorf("failed to upload file '%s': %w", path, err))
+			}
+			if uploaded {
+				processed++
 			}
 		}
 	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if failEmpty && processed == 0 {
+		return fmt.Errorf("%w: nothing to upload", nexus.ErrNoFiles)
+	}
 
 	// Print browse URL
-	linkDest := strings.TrimSuffix(destination, "/")
-
-	linkDest = strings.ReplaceAll(linkDest, "/", "%2F")
-	linkURL := fmt.Sprintf("%s/#browse/browse:%s:%s", cfg.GetNexusAddress(), repository, linkDest)
+	linkURL := client.BrowseURL(repository, destination)
 
 	if !quiet {
-		fmt.Println("Success!")
+		fmt.Fprintln(os.Stderr, "Success!")
 		fmt.Println(linkURL)
 	}
 	return nil
 }
+
+// checkPlaceholderName checks that an empty-directory placeholder is a plain file name
+func checkPlaceholderName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return fmt.Errorf("invalid placeholder name '%s': must be a file name without slashes", name)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package asset
+
+import (
+	"fmt"
+	"os"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var SearchCmd = &cobra.Command{
+	Use:   "search [flags]",
+	Short: "Search assets in Nexus repository by criteria",
+	Long: `Search assets in Nexus OSS repository using the Nexus search API.
+Unlike list, search does not require knowing the directory of an asset;
+matches from anywhere in the repository are printed. Criteria are combined.
+
+Examples:
+  # Find assets by keyword
+  nexus-util asset search -a http://nexus.example.com -r myrepo -u user -p pass --keyword foo
+
+  # Find assets by name with a wildcard
+  nexus-util asset search -a http://nexus.example.com -r myrepo -u user -p pass --name 'releases/*/app.tar.gz'
+
+  # Find assets of a Maven component version
+  nexus-util asset search -a http://nexus.example.com -r maven-releases -u user -p pass --group com.example --version 1.2.3
+
+  # Stop after the first 500 matches
+  nexus-util asset search -a http://nexus.example.com -r myrepo -u user -p pass --keyword log --max-results 500
+
+  # Exit with code 2 when nothing matches
+  nexus-util asset search -a http://nexus.example.com -r myrepo -u user -p pass --name 'releases/*/app.tar.gz' --fail-empty`,
+	Args: cobra.NoArgs,
+	RunE: runSearch,
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get search-specific flags
+	keyword, _ := cmd.Flags().GetString("keyword")
+	name, _ := cmd.Flags().GetString("name")
+	group, _ := cmd.Flags().GetString("group")
+	version, _ := cmd.Flags().GetString("version")
+	format, _ := cmd.Flags().GetString("format")
+	maxResults, _ := cmd.Flags().GetInt("max-results")
+	failEmpty, _ := cmd.Flags().GetBool("fail-empty")
+
+	if keyword == "" && name == "" && group == "" && version == "" && format == "" {
+		return fmt.Errorf("at least one of --keyword, --name, --group, --version or --format is required")
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	if maxResults < 0 {
+		return fmt.Errorf("--max-results must not be negative")
+	}
+	client.MaxResults = maxResults
+
+	assets, err := client.SearchAssets(ctx, map[string]string{
+		"repository": repository,
+		"q":          keyword,
+		"name":       name,
+		"group":      group,
+		"version":    version,
+		"format":     format,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to search assets: %w", err)
+	}
+
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Found %d matching assets in '%s':\n", len(assets), repository)
+	}
+	for _, asset := range assets {
+		fmt.Println(asset.Path)
+	}
+
+	if failEmpty && len(assets) == 0 {
+		return fmt.Errorf("%w: no assets matched the search criteria", nexus.ErrNoFiles)
+	}
+
+	return nil
+}
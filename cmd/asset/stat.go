@@ -0,0 +1,137 @@
+package asset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var StatCmd = &cobra.Command{
+	Use:   "stat [flags] <path>",
+	Short: "Show metadata of a file in Nexus repository",
+	Long: `Show size, last modification time, content type and checksums of a file
+in Nexus OSS Raw Repository. The metadata is read with a single HEAD request.
+
+Examples:
+  # Show file metadata
+  nexus-util asset stat -a http://nexus.example.com -r myrepo -u user -p pass dir/file.txt
+
+  # Print metadata as JSON
+  nexus-util asset stat -a http://nexus.example.com -r myrepo -u user -p pass --json dir/file.txt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStat,
+}
+
+func runStat(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get stat-specific flags
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	info, err := client.GetAssetInfo(ctx, repository, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to stat '%s': %w", args[0], err)
+	}
+
+	if jsonOutput {
+		jsonData, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal file info: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	fmt.Printf("Path:          %s\n", info.Path)
+	fmt.Printf("Size:          %d bytes\n", info.Size)
+	if info.LastModified != nil {
+		fmt.Printf("Last-Modified: %s\n", info.LastModified.Format(time.RFC3339))
+	}
+	if info.ContentType != "" {
+		fmt.Printf("Content-Type:  %s\n", info.ContentType)
+	}
+
+	algorithms := make([]string, 0, len(info.Checksum))
+	for alg := range info.Checksum {
+		algorithms = append(algorithms, alg)
+	}
+	sort.Strings(algorithms)
+	for _, alg := range algorithms {
+		fmt.Printf("%-14s %s\n", alg+":", info.Checksum[alg])
+	}
+
+	return nil
+}
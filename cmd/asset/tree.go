@@ -0,0 +1,250 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var TreeCmd = &cobra.Command{
+	Use:   "tree [subdir]",
+	Short: "Print the directory tree of a Nexus repository",
+	Long: `Print the files of a directory (or the whole repository) in Nexus OSS Raw
+Repository as an indented tree, like the tree command. Directories that only
+contain a single directory are collapsed into one line, e.g. "org/example/app/".
+
+Examples:
+  # Print the whole repository
+  nexus-util asset tree -a http://nexus.example.com -r myrepo -u user -p pass
+
+  # Print a subdirectory two levels deep
+  nexus-util asset tree -a http://nexus.example.com -r myrepo -u user -p pass --depth 2 releases/
+
+  # Print only the directory structure
+  nexus-util asset tree -a http://nexus.example.com -r myrepo -u user -p pass --dirs-only`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTree,
+}
+
+func runTree(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get common flags
+	address, _ := cmd.Flags().GetString("address")
+	repository, _ := cmd.Flags().GetString("repository")
+	username, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	configPath, _ := cmd.Flags().GetString("config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	// Get tree-specific flags
+	depth, _ := cmd.Flags().GetInt("depth")
+	dirsOnly, _ := cmd.Flags().GetBool("dirs-only")
+	if depth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+
+	subdir := ""
+	if len(args) > 0 {
+		subdir = args[0]
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         username,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+		"repository":   repository,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	repository, err = cfg.RequireRepository()
+	if err != nil {
+		return err
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	return runTreeWithClient(ctx, client, repository, subdir, treeOptions{Depth: depth, DirsOnly: dirsOnly}, os.Stdout)
+}
+
+// treeOptions controls what runTreeWithClient prints
+type treeOptions struct {
+	// Depth limits the number of levels printed; 0 means unlimited
+	Depth int
+	// DirsOnly leaves files out of the tree
+	DirsOnly bool
+}
+
+// treeNode is a directory or file in the tree built from asset paths
+type treeNode struct {
+	file     bool
+	children map[string]*treeNode
+}
+
+// runTreeWithClient lists the files below subdir and writes them to out as a tree
+func runTreeWithClient(ctx context.Context, client *nexus.NexusClient, repository, subdir string, opts treeOptions, out io.Writer) error {
+	files, err := client.GetFilesInDirectory(ctx, repository, subdir)
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	root := buildTree(files, subdir)
+	label := repository + "/"
+	if dir := strings.Trim(subdir, "/"); dir != "" {
+		label += dir + "/"
+	}
+	fmt.Fprintln(out, label)
+
+	p := &treePrinter{out: out, opts: opts}
+	p.print(root, "", 1)
+
+	if opts.DirsOnly {
+		fmt.Fprintf(out, "\n%d directories\n", p.dirs)
+	} else {
+		fmt.Fprintf(out, "\n%d directories, %d files\n", p.dirs, p.files)
+	}
+	return nil
+}
+
+// buildTree splits the asset paths below subdir on "/" into a tree
+func buildTree(files []nexus.Asset, subdir string) *treeNode {
+	root := &treeNode{children: map[string]*treeNode{}}
+	prefix := strings.Trim(subdir, "/")
+	for _, file := range files {
+		rel := strings.TrimPrefix(file.Path, "/")
+		if prefix != "" {
+			if rel == prefix {
+				// subdir names a file itself
+				rel = rel[strings.LastIndex(rel, "/")+1:]
+			} else {
+				rel = strings.TrimPrefix(rel, prefix+"/")
+			}
+		}
+
+		node := root
+		parts := strings.Split(rel, "/")
+		for i, part := range parts {
+			if part == "" {
+				continue
+			}
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{children: map[string]*treeNode{}}
+				node.children[part] = child
+			}
+			if i == len(parts)-1 {
+				child.file = true
+			}
+			node = child
+		}
+	}
+	return root
+}
+
+// treePrinter renders a tree with branch characters and counts what it printed
+type treePrinter struct {
+	out   io.Writer
+	opts  treeOptions
+	dirs  int
+	files int
+}
+
+// visible returns the sorted names of the children of node that are printed
+func (p *treePrinter) visible(node *treeNode) []string {
+	names := make([]string, 0, len(node.children))
+	for name, child := range node.children {
+		if p.opts.DirsOnly && child.file {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *treePrinter) print(node *treeNode, indent string, level int) {
+	names := p.visible(node)
+	for i, name := range names {
+		child := node.children[name]
+		label := name
+
+		if child.file {
+			p.files++
+		} else {
+			p.dirs++
+			// Collapse directories that only hold a single directory
+			for {
+				next := p.visible(child)
+				if len(next) != 1 || child.children[next[0]].file {
+					break
+				}
+				label += "/" + next[0]
+				child = child.children[next[0]]
+				p.dirs++
+			}
+			label += "/"
+		}
+
+		branch, nextIndent := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, nextIndent = "└── ", "    "
+		}
+		fmt.Fprintf(p.out, "%s%s%s\n", indent, branch, label)
+
+		if !child.file && (p.opts.Depth == 0 || level < p.opts.Depth) {
+			p.print(child, indent+nextIndent, level+1)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package asset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var VerifyManifestCmd = &cobra.Command{
+	Use:   "verify-manifest <manifest> <dir>",
+	Short: "Check local files against a download manifest",
+	Long: `Check the files listed in a JSON manifest written by 'asset pull --manifest'
+against their recorded sha256 checksums. File paths in the manifest are resolved
+below dir, the directory the files were downloaded to. Missing and modified files
+are listed, and the command fails if there are any. No requests are sent to Nexus.
+
+Examples:
+  # Download a directory with a manifest, then check it later, e.g. in CI
+  nexus-util asset pull -a http://nexus.example.com -r myrepo -u user -p pass -d ./downloads --manifest manifest.json dir/
+  nexus-util verify-manifest manifest.json ./downloads`,
+	Args: cobra.ExactArgs(2),
+	RunE: runVerifyManifest,
+}
+
+func runVerifyManifest(cmd *cobra.Command, args []string) error {
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("error reading manifest: %w", err)
+	}
+	var manifest nexus.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("error parsing manifest '%s': %w", args[0], err)
+	}
+
+	if info, err := os.Stat(args[1]); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory '%s' doesn't exist", args[1])
+	}
+
+	return verifyManifest(&manifest, args[1], quiet, os.Stdout)
+}
+
+// verifyManifest recomputes the checksum of every manifest entry below dir and
+// writes the missing and mismatching files to out
+func verifyManifest(manifest *nexus.Manifest, dir string, quiet bool, out io.Writer) error {
+	var missing, mismatched int
+	for _, entry := range manifest.Files {
+		localPath := filepath.FromSlash(entry.File)
+		if !filepath.IsLocal(localPath) {
+			// A manifest must not point outside the checked directory
+			fmt.Fprintf(out, "INVALID   %s\n", entry.File)
+			mismatched++
+			continue
+		}
+
+		actual, err := computeLocalHash(filepath.Join(dir, localPath), "sha256")
+		switch {
+		case os.IsNotExist(err):
+			fmt.Fprintf(out, "MISSING   %s\n", entry.File)
+			missing++
+		case err != nil:
+			return fmt.Errorf("failed to hash '%s': %w", entry.File, err)
+		case actual != entry.SHA256:
+			fmt.Fprintf(out, "MISMATCH  %s\n", entry.File)
+			mismatched++
+		}
+	}
+
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Checked %d files from %s (repository '%s'): %d missing, %d mismatched\n",
+			len(manifest.Files), manifest.Source, manifest.Repository, missing, mismatched)
+	}
+
+	if missing > 0 || mismatched > 0 {
+		return fmt.Errorf("manifest verification failed: %d missing, %d mismatched", missing, mismatched)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package blob
 
 import (
 	"fmt"
+	"os"
 
 	"nexus-util/config"
 	"nexus-util/nexus"
@@ -26,6 +27,8 @@ Examples:
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	blobStoreName := args[0]
 
 	// Get flags
@@ -33,6 +36,15 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	address, _ := cmd.Flags().GetString("address")
 	user, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
@@ -54,6 +66,15 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		"nexusAddress": address,
 		"user":         user,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
 	}
 
 	cfg, err := config.LoadConfigWithFlags(configPath, flags)
@@ -67,7 +88,23 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create Nexus client
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
 
 	// Create blob store configuration
 	blobStoreConfig := nexus.BlobStoreConfig{
@@ -84,12 +121,12 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create blob store
-	if err := client.CreateBlobStore(blobStoreConfig); err != nil {
+	if err := client.CreateBlobStore(ctx, blobStoreConfig); err != nil {
 		return fmt.Errorf("failed to create blob store: %w", err)
 	}
 
 	if !quiet {
-		fmt.Printf("Blob store '%s' created successfully\n", blobStoreName)
+		fmt.Fprintf(os.Stderr, "Blob store '%s' created successfully\n", blobStoreName)
 	}
 
 	return nil
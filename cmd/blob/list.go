@@ -30,11 +30,22 @@ Examples:
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get flags
 	configPath, _ := cmd.Flags().GetString("config")
 	address, _ := cmd.Flags().GetString("address")
 	user, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
@@ -44,6 +55,15 @@ func runList(cmd *cobra.Command, args []string) error {
 		"nexusAddress": address,
 		"user":         user,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
 	}
 
 	cfg, err := config.LoadConfigWithFlags(configPath, flags)
@@ -57,17 +77,33 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create Nexus client
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
 
 	// List blob stores
-	blobStores, err := client.ListBlobStores()
+	blobStores, err := client.ListBlobStores(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list blob stores: %w", err)
 	}
 
 	// Display results
 	if len(blobStores) == 0 {
-		fmt.Println("No blob stores found.")
+		fmt.Fprintln(os.Stderr, "No blob stores found.")
 		return nil
 	}
 
@@ -85,27 +121,14 @@ func runList(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n",
 			blobStore.Name,
 			blobStore.Type,
-			formatBytes(blobStore.AvailableSpaceInBytes),
-			formatBytes(blobStore.TotalSizeInBytes),
+			nexus.FormatBytes(blobStore.AvailableSpaceInBytes),
+			nexus.FormatBytes(blobStore.TotalSizeInBytes),
 			blobStore.BlobCount)
 	}
 
 	return nil
 }
 
-func formatBytes(bytes uint64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
-
 func init() {
 	BlobCmd.AddCommand(ListCmd)
 }
@@ -0,0 +1,175 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var ComponentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Component management commands",
+	Long: `Commands for managing Nexus components. A component is a versioned package
+made of one or more assets, such as a Maven artifact or an npm package.`,
+}
+
+var ComponentLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List components in a repository",
+	Long: `List all components of a repository with their IDs, coordinates and number of assets.
+The component IDs can be passed to 'component rm'.
+
+Examples:
+  # List components of a repository
+  nexus-util component ls -r maven-releases -a http://nexus.example.com -u user -p pass
+
+  # List components as tab-separated lines for scripts
+  nexus-util component ls -r maven-releases --output plain -a http://nexus.example.com -u user -p pass
+
+  # List components with their assets as JSON
+  nexus-util component ls -r maven-releases --output json -a http://nexus.example.com -u user -p pass`,
+	Args: cobra.NoArgs,
+	RunE: runList,
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	repository, _ := cmd.Flags().GetString("repository")
+	output, _ := cmd.Flags().GetString("output")
+
+	if repository == "" {
+		return fmt.Errorf("repository is required, use --repository")
+	}
+
+	// Load configuration
+	flags := map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	}
+
+	cfg, err := config.LoadConfigWithFlags(configPath, flags)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	return runListWithClient(ctx, client, repository, output, os.Stdout)
+}
+
+// runListWithClient lists the components of repository with an already
+// configured client and writes them to out as an aligned table, plain
+// tab-separated lines or JSON
+func runListWithClient(ctx context.Context, client *nexus.NexusClient, repository, format string, out io.Writer) error {
+	switch format {
+	case "table", "plain", "json":
+	default:
+		return fmt.Errorf("invalid output format '%s': must be table, plain or json", format)
+	}
+
+	components, err := client.ListComponents(ctx, repository)
+	if err != nil {
+		return fmt.Errorf("failed to list components: %w", err)
+	}
+
+	switch format {
+	case "json":
+		if components == nil {
+			components = []nexus.Component{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(components)
+	case "plain":
+		for _, component := range components {
+			fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%d\n", component.ID, component.Group, component.Name, component.Version, len(component.Assets))
+		}
+		return nil
+	}
+
+	if len(components) == 0 {
+		fmt.Fprintf(os.Stderr, "No components found in repository '%s'.\n", repository)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "ID\tGROUP\tNAME\tVERSION\tASSETS")
+	fmt.Fprintln(w, "--\t-----\t----\t-------\t------")
+	for _, component := range components {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n",
+			component.ID,
+			component.Group,
+			component.Name,
+			component.Version,
+			len(component.Assets))
+	}
+
+	return nil
+}
+
+func init() {
+	ComponentLsCmd.Flags().StringP("repository", "r", "", "Repository to list components from")
+	ComponentLsCmd.Flags().String("output", "table", "Output format: table, plain or json")
+
+	ComponentCmd.AddCommand(ComponentLsCmd)
+}
@@ -0,0 +1,135 @@
+package component
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var ComponentRmCmd = &cobra.Command{
+	Use:   "rm <id>...",
+	Short: "Delete components",
+	Long: `Delete components and all of their assets by component ID.
+Component IDs are shown by 'component ls'. Unless --force is given, the
+deletion has to be confirmed.
+
+Examples:
+  # Delete a component after confirmation
+  nexus-util component rm bWF2ZW4tcmVsZWFzZXM6ZjEy -a http://nexus.example.com -u user -p pass
+
+  # Delete several components without confirmation (for scripts)
+  nexus-util component rm --force bWF2ZW4tcmVsZWFzZXM6ZjEy bWF2ZW4tcmVsZWFzZXM6YTk4 -a http://nexus.example.com -u user -p pass
+
+  # Dry run to see what would be deleted
+  nexus-util component rm --dry bWF2ZW4tcmVsZWFzZXM6ZjEy -a http://nexus.example.com -u user -p pass`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRm,
+}
+
+func runRm(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	force, _ := cmd.Flags().GetBool("force")
+
+	// Load configuration
+	flags := map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	}
+
+	cfg, err := config.LoadConfigWithFlags(configPath, flags)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	// Ask for confirmation unless forced; a dry run deletes nothing
+	if !force && !dryRun {
+		fmt.Fprintf(os.Stderr, "This will permanently delete %d components and all of their assets.\n", len(args))
+		fmt.Fprint(os.Stderr, "Continue? [y/N]: ")
+		answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && answer == "" {
+			return fmt.Errorf("error reading confirmation: %w", err)
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+		default:
+			return fmt.Errorf("deletion not confirmed, nothing deleted")
+		}
+	}
+
+	// Delete components
+	for _, id := range args {
+		if err := client.DeleteComponent(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete component: %w", err)
+		}
+
+		if !quiet && !dryRun {
+			fmt.Fprintf(os.Stderr, "Component '%s' deleted successfully\n", id)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	ComponentRmCmd.Flags().BoolP("force", "f", false, "Delete without asking for confirmation")
+
+	ComponentCmd.AddCommand(ComponentRmCmd)
+}
@@ -0,0 +1,11 @@
+package configcmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration commands",
+	Long:  "Commands for checking the configuration file and the settings it resolves to",
+}
@@ -0,0 +1,156 @@
+package configcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"nexus-util/config"
+
+	"github.com/spf13/cobra"
+)
+
+var ConfigShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration with secrets masked",
+	Long: `Print the configuration that commands actually use after merging the config
+file, the selected profile, environment variables and flags. The password and
+token are always printed as **** and a proxy password is masked, so the output
+can be shared when debugging which setting wins.
+
+Examples:
+  # Show the effective configuration
+  nexus-util config show
+
+  # Show what a profile combined with an environment variable resolves to
+  NEXUS_TIMEOUT=2h nexus-util config show --profile staging
+
+  # Print it as JSON
+  nexus-util config show --json`,
+	Args: cobra.NoArgs,
+	RunE: runShow,
+}
+
+// effectiveConfig is the printed form of a redacted configuration
+type effectiveConfig struct {
+	ConfigFile string `json:"configFile"`
+	Profile    string `json:"profile"`
+	Address    string `json:"nexusAddress"`
+	User       string `json:"user"`
+	Password   string `json:"password"`
+	Token      string `json:"token"`
+	ClientCert string `json:"clientCert"`
+	ClientKey  string `json:"clientKey"`
+	CABundle   string `json:"caBundle"`
+	Proxy      string `json:"proxy"`
+	Timeout    string `json:"timeout"`
+	UserAgent  string `json:"userAgent"`
+	BasePath   string `json:"basePath"`
+	Repository string `json:"repository"`
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		configPath += " (not found)"
+	}
+
+	return writeConfig(os.Stdout, cfg, configPath, jsonOutput)
+}
+
+// writeConfig prints the redacted configuration as aligned lines or JSON
+func writeConfig(out io.Writer, cfg *config.Config, configFile string, jsonOutput bool) error {
+	redacted := cfg.Redacted()
+	effective := effectiveConfig{
+		ConfigFile: configFile,
+		Profile:    redacted.Profile,
+		Address:    redacted.GetNexusAddress(),
+		User:       redacted.GetUser(),
+		Password:   redacted.GetPassword(),
+		Token:      redacted.GetToken(),
+		ClientCert: redacted.GetClientCert(),
+		ClientKey:  redacted.GetClientKey(),
+		CABundle:   redacted.GetCABundle(),
+		Proxy:      redacted.GetProxy(),
+		Timeout:    redacted.Timeout,
+		UserAgent:  redacted.GetUserAgent(),
+		BasePath:   redacted.GetBasePath(),
+		Repository: redacted.Repository,
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(effective)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, line := range []struct{ name, value string }{
+		{"config file", effective.ConfigFile},
+		{"profile", effective.Profile},
+		{"address", effective.Address},
+		{"user", effective.User},
+		{"password", effective.Password},
+		{"token", effective.Token},
+		{"client cert", effective.ClientCert},
+		{"client key", effective.ClientKey},
+		{"ca bundle", effective.CABundle},
+		{"proxy", effective.Proxy},
+		{"timeout", effective.Timeout},
+		{"user agent", effective.UserAgent},
+		{"base path", effective.BasePath},
+		{"repository", effective.Repository},
+	} {
+		value := line.value
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(w, "%s:\t%s\n", line.name, value)
+	}
+	return w.Flush()
+}
+
+func init() {
+	ConfigShowCmd.Flags().Bool("json", false, "Print the configuration as JSON")
+
+	ConfigCmd.AddCommand(ConfigShowCmd)
+}
@@ -0,0 +1,184 @@
+package configcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var ConfigValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration and the connection to Nexus",
+	Long: `Load the configuration the same way every other command does (config file,
+selected profile, environment variables and flags), validate it and check that
+the Nexus server is reachable and accepts the credentials. Each check is
+printed as OK, FAIL or SKIP; the command fails if any check fails.
+
+Examples:
+  # Check the default config file (~/.nexus-util.yaml)
+  nexus-util config validate
+
+  # Check another config file and profile
+  nexus-util config validate --config ./ci-config.yaml --profile staging`,
+	Args: cobra.NoArgs,
+	RunE: runValidate,
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	out := os.Stdout
+	v := &validator{out: out}
+
+	// Check that the config file can be read
+	filePath := configPath
+	if filePath == "" {
+		filePath = config.DefaultConfigPath()
+	}
+	switch _, err := os.Stat(filePath); {
+	case err == nil:
+		v.ok("config file", filePath)
+	case os.IsNotExist(err) && configPath == "":
+		v.skip("config file", fmt.Sprintf("%s not found, using flags and environment variables", filePath))
+	default:
+		v.fail("config file", err)
+	}
+
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	})
+	if err != nil {
+		v.fail("load configuration", err)
+		return v.result(cmd)
+	}
+	if err := cfg.Validate(); err != nil {
+		v.fail("settings", err)
+		return v.result(cmd)
+	}
+	v.ok("settings", "address "+cfg.GetNexusAddress())
+
+	// Create Nexus client; TLS and proxy settings are checked here
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), true, false, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		v.fail("client", err)
+		return v.result(cmd)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		v.fail("client", err)
+		return v.result(cmd)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	// Report problems right away instead of retrying them
+	client.Retry = nexus.NoRetryConfig()
+
+	hasCredentials := cfg.GetToken() != "" || cfg.GetUser() != ""
+	checkConnection(ctx, client, hasCredentials, v)
+	return v.result(cmd)
+}
+
+// checkConnection checks that the server is reachable and, when credentials
+// are configured, that it accepts them
+func checkConnection(ctx context.Context, client *nexus.NexusClient, hasCredentials bool, v *validator) {
+	status, err := client.CheckStatus(ctx, false)
+	if err == nil && !status.Available {
+		err = fmt.Errorf("server is not available")
+	}
+	if err != nil {
+		v.fail("server reachable", err)
+		v.skip("credentials", "server not reachable")
+		return
+	}
+	v.ok("server reachable", client.BaseURL)
+
+	if !hasCredentials {
+		v.skip("credentials", "no user or token configured")
+		return
+	}
+	switch err := client.CheckCredentials(ctx); {
+	case err == nil:
+		v.ok("credentials", "accepted")
+	case errors.Is(err, nexus.ErrCredentialsRejected):
+		v.fail("credentials", fmt.Errorf("%w by %s", err, client.BaseURL))
+	default:
+		v.fail("credentials", err)
+	}
+}
+
+// validator prints the outcome of each check and counts the failures
+type validator struct {
+	out    io.Writer
+	checks int
+	failed int
+}
+
+func (v *validator) ok(check, detail string) {
+	v.checks++
+	fmt.Fprintf(v.out, "OK    %s: %s\n", check, detail)
+}
+
+func (v *validator) skip(check, reason string) {
+	fmt.Fprintf(v.out, "SKIP  %s: %s\n", check, reason)
+}
+
+func (v *validator) fail(check string, err error) {
+	v.checks++
+	v.failed++
+	fmt.Fprintf(v.out, "FAIL  %s: %v\n", check, err)
+}
+
+// result returns an error when any check failed
+func (v *validator) result(cmd *cobra.Command) error {
+	if v.failed == 0 {
+		return nil
+	}
+	// Failed checks are results, not usage mistakes
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%d of %d checks failed", v.failed, v.checks)
+}
+
+func init() {
+	ConfigCmd.AddCommand(ConfigValidateCmd)
+}
@@ -2,6 +2,7 @@ package initcmd
 
 import (
 	"fmt"
+	"os"
 
 	"nexus-util/config"
 	"github.com/spf13/cobra"
@@ -23,8 +24,20 @@ Examples:
   # Initialize with custom config file location
   nexus-util init --config ./my-config.yaml --address http://nexus.example.com --user myuser --password mypass
 
+  # Initialize with a default repository for asset commands
+  nexus-util init --address http://nexus.example.com --user myuser --repository myrepo
+
   # Initialize without password (will be prompted)
-  nexus-util init --address http://nexus.example.com --user myuser`,
+  nexus-util init --address http://nexus.example.com --user myuser
+
+  # Initialize with a bearer token instead of a password
+  nexus-util init --address http://nexus.example.com --user myuser --token mytoken
+
+  # Keep the password in the OS keyring instead of the config file
+  nexus-util init --address http://nexus.example.com --user myuser --store-keyring
+
+  # Add a named profile to an existing config file
+  nexus-util init --profile staging --address http://staging.example.com --user myuser`,
 	RunE: runInit,
 }
 
@@ -33,17 +46,26 @@ func runInit(cmd *cobra.Command, _ []string) error {
 	address, _ := cmd.Flags().GetString("address")
 	user, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	repository, _ := cmd.Flags().GetString("repository")
 	configPath, _ := cmd.Flags().GetString("config")
+	profile, _ := cmd.Flags().GetString("profile")
+	storeKeyring, _ := cmd.Flags().GetBool("store-keyring")
 
-	// Prompt for password if not provided
-	if password == "" {
-		fmt.Print("Enter password: ")
+	// Prompt for password if not provided (a token replaces the password)
+	if password == "" && token == "" {
+		fmt.Fprint(os.Stderr, "Enter password: ")
 		var err error
 		password, err = readPassword()
 		if err != nil {
 			return fmt.Errorf("error reading password: %w", err)
 		}
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 	}
 
 	// Create config
@@ -51,6 +73,13 @@ func runInit(cmd *cobra.Command, _ []string) error {
 		NexusAddress: address,
 		User:         user,
 		Password:     password,
+		Token:        token,
+		ClientCert:   clientCert,
+		ClientKey:    clientKey,
+		CABundle:     caBundle,
+		Proxy:        proxy,
+		Timeout:      timeout,
+		Repository:   repository,
 	}
 
 	// Validate config
@@ -58,6 +87,37 @@ func runInit(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Keep the password in the OS keyring and save only a reference to it
+	if storeKeyring && cfg.Password != "" {
+		ref, err := config.StorePassword(cfg.User, cfg.Password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; storing password in plaintext\n", err)
+		} else {
+			cfg.Password = ref
+		}
+	}
+
+	// Store the settings as a named profile, keeping the rest of the file
+	if profile != "" {
+		existing, err := config.ReadConfigFile(configPath)
+		if err != nil {
+			return fmt.Errorf("error reading configuration: %w", err)
+		}
+		existing.SetProfile(profile, config.Profile{
+			NexusAddress: cfg.NexusAddress,
+			User:         cfg.User,
+			Password:     cfg.Password,
+			Token:        cfg.Token,
+			ClientCert:   cfg.ClientCert,
+			ClientKey:    cfg.ClientKey,
+			CABundle:     cfg.CABundle,
+			Proxy:        cfg.Proxy,
+			Timeout:      cfg.Timeout,
+			Repository:   cfg.Repository,
+		})
+		cfg = existing
+	}
+
 	// Save config
 	if err := config.SaveConfig(cfg, configPath); err != nil {
 		return fmt.Errorf("error saving configuration: %w", err)
@@ -69,9 +129,9 @@ func runInit(cmd *cobra.Command, _ []string) error {
 		actualPath = config.DefaultConfigPath()
 	}
 
-	fmt.Printf("Configuration saved to: %s\n", actualPath)
-	fmt.Println("You can now use nexus-util commands without specifying connection details.")
-	fmt.Println("Example: nexus-util asset push -r myrepo file.txt")
+	fmt.Fprintf(os.Stderr, "Configuration saved to: %s\n", actualPath)
+	fmt.Fprintln(os.Stderr, "You can now use nexus-util commands without specifying connection details.")
+	fmt.Fprintln(os.Stderr, "Example: nexus-util asset push -r myrepo file.txt")
 
 	return nil
 }
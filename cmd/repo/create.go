@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var RepoCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a raw hosted repository",
+	Long: `Create a raw hosted repository in Nexus instance.
+This command uses the Nexus REST API to create repositories.
+
+Examples:
+  # Create a repository in the default blob store
+  nexus-util repo create myrepo -a http://nexus.example.com -u user -p pass
+
+  # Create a repository that allows redeploying files
+  nexus-util repo create myrepo -a http://nexus.example.com -u user -p pass --blob-store my-blob-store --write-policy allow
+
+  # Print the request body without creating anything
+  nexus-util repo create myrepo --dry -a http://nexus.example.com -u user -p pass`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCreate,
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	repoName := args[0]
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	blobStore, _ := cmd.Flags().GetString("blob-store")
+	writePolicy, _ := cmd.Flags().GetString("write-policy")
+	strict, _ := cmd.Flags().GetBool("strict-content-type")
+	contentDisposition, _ := cmd.Flags().GetString("content-disposition")
+
+	// Validate repository options
+	switch strings.ToLower(writePolicy) {
+	case "allow", "allow_once", "deny":
+	default:
+		return fmt.Errorf("--write-policy must be one of allow, allow_once or deny")
+	}
+	switch strings.ToUpper(contentDisposition) {
+	case "INLINE", "ATTACHMENT":
+	default:
+		return fmt.Errorf("--content-disposition must be INLINE or ATTACHMENT")
+	}
+
+	// Load configuration
+	flags := map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	}
+
+	cfg, err := config.LoadConfigWithFlags(configPath, flags)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	// Create repository
+	err = client.CreateRawRepository(ctx, repoName, nexus.RepoOptions{
+		BlobStoreName:               blobStore,
+		WritePolicy:                 writePolicy,
+		StrictContentTypeValidation: strict,
+		ContentDisposition:          contentDisposition,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	if !quiet && !dryRun {
+		fmt.Fprintf(os.Stderr, "Repository '%s' created successfully\n", repoName)
+	}
+
+	return nil
+}
+
+func init() {
+	RepoCreateCmd.Flags().String("blob-store", "default", "Blob store for the repository content")
+	RepoCreateCmd.Flags().String("write-policy", "allow_once", "Write policy: allow, allow_once or deny")
+	RepoCreateCmd.Flags().Bool("strict-content-type", true, "Reject uploads whose content does not match their MIME type")
+	RepoCreateCmd.Flags().String("content-disposition", "ATTACHMENT", "Content-Disposition of downloads: INLINE or ATTACHMENT")
+
+	RepoCmd.AddCommand(RepoCreateCmd)
+}
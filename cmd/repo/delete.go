@@ -0,0 +1,133 @@
+package repo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var RepoRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Delete a repository",
+	Long: `Delete a repository and all of its content from Nexus instance.
+Because this cannot be undone, the repository name has to be typed to confirm
+the deletion unless --force is given.
+
+Examples:
+  # Delete a repository after confirmation
+  nexus-util repo rm myrepo -a http://nexus.example.com -u user -p pass
+
+  # Delete a repository without confirmation (for scripts)
+  nexus-util repo rm myrepo --force -a http://nexus.example.com -u user -p pass
+
+  # Dry run to see what would be deleted
+  nexus-util repo rm myrepo --dry -a http://nexus.example.com -u user -p pass`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRm,
+}
+
+func runRm(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	repoName := args[0]
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	force, _ := cmd.Flags().GetBool("force")
+
+	// Load configuration
+	flags := map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	}
+
+	cfg, err := config.LoadConfigWithFlags(configPath, flags)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	// Ask for confirmation unless forced; a dry run deletes nothing
+	if !force && !dryRun {
+		fmt.Fprintf(os.Stderr, "This will permanently delete repository '%s' and all of its content.\n", repoName)
+		fmt.Fprint(os.Stderr, "Type the repository name to confirm: ")
+		answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && answer == "" {
+			return fmt.Errorf("error reading confirmation: %w", err)
+		}
+		if strings.TrimSpace(answer) != repoName {
+			return fmt.Errorf("confirmation did not match repository name, nothing deleted")
+		}
+	}
+
+	// Delete repository
+	if err := client.DeleteRepository(ctx, repoName); err != nil {
+		return fmt.Errorf("failed to delete repository: %w", err)
+	}
+
+	if !quiet && !dryRun {
+		fmt.Fprintf(os.Stderr, "Repository '%s' deleted successfully\n", repoName)
+	}
+
+	return nil
+}
+
+func init() {
+	RepoRmCmd.Flags().BoolP("force", "f", false, "Delete without asking for confirmation")
+
+	RepoCmd.AddCommand(RepoRmCmd)
+}
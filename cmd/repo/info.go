@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var RepoInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show details of a repository",
+	Long: `Show the name, format, type and URL of a repository, and its online status,
+blob store and write policy when the Nexus instance reports them.
+
+Examples:
+  # Show details of a repository
+  nexus-util repo info myrepo -a http://nexus.example.com -u user -p pass
+
+  # Show details as JSON
+  nexus-util repo info myrepo --json -a http://nexus.example.com -u user -p pass`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	repoName := args[0]
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	dryRun, _ := cmd.Flags().GetBool("dry")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	// Load configuration
+	flags := map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	}
+
+	cfg, err := config.LoadConfigWithFlags(configPath, flags)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	return runInfoWithClient(ctx, client, repoName, jsonOutput, os.Stdout)
+}
+
+// runInfoWithClient writes the details of a repository to out as aligned
+// lines or JSON; details the server did not report are left out
+func runInfoWithClient(ctx context.Context, client *nexus.NexusClient, name string, jsonOutput bool, out io.Writer) error {
+	repository, err := client.GetRepository(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(repository)
+	}
+
+	type line struct{ name, value string }
+	lines := []line{
+		{"name", repository.Name},
+		{"format", repository.Format},
+		{"type", repository.Type},
+		{"url", repository.URL},
+	}
+	if repository.Online != nil {
+		online := "no"
+		if *repository.Online {
+			online = "yes"
+		}
+		lines = append(lines, line{"online", online})
+	}
+	if repository.BlobStoreName != "" {
+		lines = append(lines, line{"blob store", repository.BlobStoreName})
+	}
+	if repository.WritePolicy != "" {
+		lines = append(lines, line{"write policy", repository.WritePolicy})
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, l := range lines {
+		fmt.Fprintf(w, "%s:\t%s\n", l.name, l.value)
+	}
+	return w.Flush()
+}
+
+func init() {
+	RepoInfoCmd.Flags().Bool("json", false, "Print the repository details as JSON")
+
+	RepoCmd.AddCommand(RepoInfoCmd)
+}
@@ -1,8 +1,12 @@
 package repo
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"nexus-util/config"
@@ -30,26 +34,58 @@ Examples:
   # List repositories in quiet mode
   nexus-util repo ls -q -a http://nexus.example.com -u user -p pass
 
+  # List repositories as tab-separated lines for scripts
+  nexus-util repo ls --output plain -a http://nexus.example.com -u user -p pass
+
+  # List only raw hosted repositories
+  nexus-util repo ls --format raw --type hosted -a http://nexus.example.com -u user -p pass
+
+  # List repositories as JSON
+  nexus-util repo ls --output json -a http://nexus.example.com -u user -p pass
+
   # List repositories with custom config file
   nexus-util repo ls -c /path/to/config.yaml`,
 	RunE: runList,
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get flags
 	configPath, _ := cmd.Flags().GetString("config")
 	address, _ := cmd.Flags().GetString("address")
 	user, _ := cmd.Flags().GetString("user")
 	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
+	output, _ := cmd.Flags().GetString("output")
+	repoFormat, _ := cmd.Flags().GetString("format")
+	repoType, _ := cmd.Flags().GetString("type")
 
 	// Load configuration
 	flags := map[string]interface{}{
 		"nexusAddress": address,
 		"user":         user,
 		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
 	}
 
 	cfg, err := config.LoadConfigWithFlags(configPath, flags)
@@ -63,45 +99,130 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create Nexus client (repository not needed for listing)
-	client := nexus.NewNexusClient(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure)
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), quiet, dryRun, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+
+	client.Debugf("List command args: %v", args)
 
-	// Debug: output args
-	client.Logf("List command args: %v", args)
+	return runListWithClient(ctx, client, output, repositoryFilter{Format: repoFormat, Type: repoType}, os.Stdout)
+}
+
+// repositoryFilter selects repositories by format and type; empty fields match
+// every repository
+type repositoryFilter struct {
+	// Format is the repository format, e.g. raw or maven2
+	Format string
+	// Type is hosted, proxy or group
+	Type string
+}
+
+// validate checks the type of the filter
+func (f repositoryFilter) validate() error {
+	switch strings.ToLower(f.Type) {
+	case "", "hosted", "proxy", "group":
+		return nil
+	default:
+		return fmt.Errorf("invalid repository type '%s': must be hosted, proxy or group", f.Type)
+	}
+}
+
+// apply returns the repositories that match the filter, ignoring case
+func (f repositoryFilter) apply(repositories []nexus.Repository) []nexus.Repository {
+	matched := make([]nexus.Repository, 0, len(repositories))
+	for _, repo := range repositories {
+		if f.Format != "" && !strings.EqualFold(repo.Format, f.Format) {
+			continue
+		}
+		if f.Type != "" && !strings.EqualFold(repo.Type, f.Type) {
+			continue
+		}
+		matched = append(matched, repo)
+	}
+	return matched
+}
+
+// runListWithClient lists the repositories that match filter with an already
+// configured client and writes them to out as an aligned table, plain
+// tab-separated lines or JSON
+func runListWithClient(ctx context.Context, client *nexus.NexusClient, format string, filter repositoryFilter, out io.Writer) error {
+	switch format {
+	case "table", "plain", "json":
+	default:
+		return fmt.Errorf("invalid output format '%s': must be table, plain or json", format)
+	}
+	if err := filter.validate(); err != nil {
+		return err
+	}
 
 	// List repositories
-	repositories, err := client.ListRepositories()
+	repositories, err := client.ListRepositories(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list repositories: %w", err)
 	}
+	repositories = filter.apply(repositories)
 
 	// Display results
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(repositories)
+	case "plain":
+		for _, repo := range repositories {
+			fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", repo.Name, repo.Format, repo.Type, repo.URL)
+		}
+		return nil
+	}
+
 	if len(repositories) == 0 {
-		fmt.Println("No repositories found.")
+		if filter != (repositoryFilter{}) {
+			fmt.Fprintln(os.Stderr, "No repositories match the format and type filters.")
+		} else {
+			fmt.Fprintln(os.Stderr, "No repositories found.")
+		}
 		return nil
 	}
 
 	// Create tabwriter for formatted output
 	padding := 2
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', 0)
+	w := tabwriter.NewWriter(out, 0, 0, padding, ' ', 0)
 	defer w.Flush()
 
 	// Print header
-	fmt.Fprintln(w, "NAME\tFORMAT\tTYPE\tBROWSER")
+	fmt.Fprintln(w, "NAME\tFORMAT\tTYPE\tURL")
 	fmt.Fprintln(w, "----\t------\t----\t---")
 
 	// Print repositories
 	for _, repo := range repositories {
-		browseUrl := client.BaseURL + "/#browse/browse:" + repo.Name
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
 			repo.Name,
 			repo.Format,
 			repo.Type,
-			browseUrl)
+			repo.URL)
 	}
 
 	return nil
 }
 
 func init() {
+	RepoLsCmd.Flags().String("output", "table", "Output format: table, plain or json")
+	RepoLsCmd.Flags().String("format", "", "Only list repositories of this format, e.g. raw or maven2")
+	RepoLsCmd.Flags().String("type", "", "Only list repositories of this type: hosted, proxy or group")
+
 	RepoCmd.AddCommand(RepoLsCmd)
 }
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"nexus-util/nexus"
+)
+
+func TestRunListWithClientFormats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name":"raw-hosted","format":"raw","type":"hosted","url":"http://nexus/repository/raw-hosted"},
+			{"name":"maven-central","format":"maven2","type":"proxy","url":"http://nexus/repository/maven-central"},
+			{"name":"npm-all","format":"npm","type":"group","url":"http://nexus/repository/npm-all"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	var out bytes.Buffer
+	if err := runListWithClient(context.Background(), client, "table", repositoryFilter{}, &out); err != nil {
+		t.Fatalf("table output failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected header, separator and 3 rows, got %q", out.String())
+	}
+	if !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[0], "URL") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	// Columns are padded so every row starts its FORMAT column at the same offset
+	column := strings.Index(lines[0], "FORMAT")
+	for _, line := range lines[2:] {
+		if line[column-1] != ' ' || line[column] == ' ' {
+			t.Errorf("row %q is not aligned with header column at %d", line, column)
+		}
+	}
+
+	out.Reset()
+	if err := runListWithClient(context.Background(), client, "plain", repositoryFilter{}, &out); err != nil {
+		t.Fatalf("plain output failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "raw-hosted\traw\thosted\thttp://nexus/repository/raw-hosted\n") {
+		t.Errorf("unexpected plain output %q", out.String())
+	}
+
+	out.Reset()
+	if err := runListWithClient(context.Background(), client, "json", repositoryFilter{}, &out); err != nil {
+		t.Fatalf("json output failed: %v", err)
+	}
+	var repositories []nexus.Repository
+	if err := json.Unmarshal(out.Bytes(), &repositories); err != nil {
+		t.Fatalf("expected JSON array, got %q: %v", out.String(), err)
+	}
+	if len(repositories) != 3 || repositories[1].Format != "maven2" || repositories[2].Type != "group" {
+		t.Errorf("unexpected repositories: %+v", repositories)
+	}
+
+	if err := runListWithClient(context.Background(), client, "yaml", repositoryFilter{}, &out); err == nil {
+		t.Error("expected error for unknown output format")
+	}
+}
+
+func TestRunListWithClientFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name":"raw-hosted","format":"raw","type":"hosted","url":"http://nexus/repository/raw-hosted"},
+			{"name":"raw-proxy","format":"raw","type":"proxy","url":"http://nexus/repository/raw-proxy"},
+			{"name":"maven-releases","format":"maven2","type":"hosted","url":"http://nexus/repository/maven-releases"},
+			{"name":"npm-all","format":"npm","type":"group","url":"http://nexus/repository/npm-all"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	tests := []struct {
+		filter repositoryFilter
+		want   []string
+	}{
+		{repositoryFilter{}, []string{"raw-hosted", "raw-proxy", "maven-releases", "npm-all"}},
+		{repositoryFilter{Format: "raw"}, []string{"raw-hosted", "raw-proxy"}},
+		{repositoryFilter{Type: "hosted"}, []string{"raw-hosted", "maven-releases"}},
+		{repositoryFilter{Format: "RAW", Type: "Hosted"}, []string{"raw-hosted"}},
+		{repositoryFilter{Format: "docker"}, []string{}},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := runListWithClient(context.Background(), client, "json", tt.filter, &out); err != nil {
+			t.Fatalf("%+v: list failed: %v", tt.filter, err)
+		}
+		var repositories []nexus.Repository
+		if err := json.Unmarshal(out.Bytes(), &repositories); err != nil {
+			t.Fatalf("%+v: expected JSON array, got %q: %v", tt.filter, out.String(), err)
+		}
+		names := []string{}
+		for _, repo := range repositories {
+			names = append(names, repo.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%+v: expected %v, got %v", tt.filter, tt.want, names)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runListWithClient(context.Background(), client, "json", repositoryFilter{Type: "virtual"}, &out); err == nil {
+		t.Error("expected error for unknown repository type")
+	}
+}
+
+func TestRunInfoWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/service/rest/v1/repositories":
+			_, _ = w.Write([]byte(`[
+				{"name":"raw-hosted","format":"raw","type":"hosted","url":"http://nexus/repository/raw-hosted"},
+				{"name":"npm-all","format":"npm","type":"group","url":"http://nexus/repository/npm-all"}
+			]`))
+		case "/service/rest/v1/repositories/raw/hosted/raw-hosted":
+			_, _ = w.Write([]byte(`{"name":"raw-hosted","online":true,"storage":{"blobStoreName":"default","strictContentTypeValidation":true,"writePolicy":"ALLOW_ONCE"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+
+	var out bytes.Buffer
+	if err := runInfoWithClient(context.Background(), client, "raw-hosted", false, &out); err != nil {
+		t.Fatalf("info failed: %v", err)
+	}
+	for _, want := range []string{"name:          raw-hosted\n", "format:        raw\n", "online:        yes\n", "blob store:    default\n", "write policy:  ALLOW_ONCE\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+
+	// Without a detailed API only the list entry is shown
+	out.Reset()
+	if err := runInfoWithClient(context.Background(), client, "npm-all", true, &out); err != nil {
+		t.Fatalf("info failed: %v", err)
+	}
+	var repository nexus.Repository
+	if err := json.Unmarshal(out.Bytes(), &repository); err != nil {
+		t.Fatalf("expected JSON object, got %q: %v", out.String(), err)
+	}
+	if repository.Type != "group" || repository.Online != nil || repository.BlobStoreName != "" {
+		t.Errorf("unexpected repository: %+v", repository)
+	}
+
+	err := runInfoWithClient(context.Background(), client, "missing", false, &out)
+	if !errors.Is(err, nexus.ErrRepositoryNotFound) {
+		t.Errorf("expected ErrRepositoryNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package statuscmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+
+	"github.com/spf13/cobra"
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check whether the Nexus instance is up",
+	Long: `Query the Nexus status endpoint and report whether the instance is up and,
+with --writable, whether it also accepts writes. The status endpoints need no
+authentication or repository permissions, so the command works as a liveness
+probe for monitoring and smoke tests. It exits with code 1 when the instance is
+unreachable, not available or, with --writable, read-only.
+
+Examples:
+  # Check that the configured Nexus instance is up
+  nexus-util status
+
+  # Also require the instance to accept writes
+  nexus-util status --writable
+
+  # Probe another instance and print the result as JSON
+  nexus-util status -a http://nexus.example.com --writable --json`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+// statusReport is the printed form of a status check
+type statusReport struct {
+	Address        string `json:"address"`
+	Available      bool   `json:"available"`
+	Writable       *bool  `json:"writable,omitempty"`
+	ResponseTimeMs int64  `json:"responseTimeMs"`
+	Error          string `json:"error,omitempty"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Get flags
+	configPath, _ := cmd.Flags().GetString("config")
+	address, _ := cmd.Flags().GetString("address")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	token, _ := cmd.Flags().GetString("token")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	checkWritable, _ := cmd.Flags().GetBool("writable")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	// Load configuration
+	cfg, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
+		"nexusAddress": address,
+		"user":         user,
+		"password":     password,
+		"token":        token,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     basePath,
+		"timeout":      timeout,
+		"profile":      profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Create Nexus client
+	client, err := nexus.NewNexusClientWithTLS(cfg.GetNexusAddress(), cfg.GetUser(), cfg.GetPassword(), true, false, insecure, nexus.TLSOptions{
+		ClientCertFile: cfg.GetClientCert(),
+		ClientKeyFile:  cfg.GetClientKey(),
+		CABundleFile:   cfg.GetCABundle(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Nexus client: %w", err)
+	}
+	client.Token = cfg.GetToken()
+	client.UserAgent = cfg.GetUserAgent()
+	client.BasePath = cfg.GetBasePath()
+	if err := client.SetProxy(cfg.GetProxy()); err != nil {
+		return fmt.Errorf("failed to configure proxy: %w", err)
+	}
+	if timeout, ok := cfg.GetTimeout(); ok {
+		client.SetTimeout(timeout)
+	}
+	// A probe reports the current state instead of waiting for it to change
+	client.Retry = nexus.NoRetryConfig()
+
+	info, checkErr := client.CheckStatus(ctx, checkWritable)
+	report := newStatusReport(client.BaseURL, info, checkErr, checkWritable)
+
+	var out io.Writer = os.Stdout
+	if quiet && !jsonOutput {
+		out = io.Discard
+	}
+	if err := writeStatus(out, report, jsonOutput); err != nil {
+		return err
+	}
+
+	if err := statusError(report, checkWritable); err != nil {
+		// An unhealthy server is a result, not a usage mistake
+		cmd.SilenceUsage = true
+		return err
+	}
+	return nil
+}
+
+// newStatusReport combines the outcome of a status check into a report
+func newStatusReport(address string, info nexus.StatusInfo, checkErr error, checkWritable bool) statusReport {
+	report := statusReport{
+		Address:        address,
+		Available:      info.Available,
+		ResponseTimeMs: info.ResponseTime.Milliseconds(),
+	}
+	if checkErr != nil {
+		report.Error = checkErr.Error()
+	}
+	if checkWritable && info.Available && checkErr == nil {
+		writable := info.Writable
+		report.Writable = &writable
+	}
+	return report
+}
+
+// writeStatus prints the report as a single line or as JSON
+func writeStatus(out io.Writer, report statusReport, jsonOutput bool) error {
+	if jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	switch {
+	case report.Error != "":
+		fmt.Fprintf(out, "%s is unreachable: %s\n", report.Address, report.Error)
+	case !report.Available:
+		fmt.Fprintf(out, "%s is down\n", report.Address)
+	case report.Writable == nil:
+		fmt.Fprintf(out, "%s is up (%dms)\n", report.Address, report.ResponseTimeMs)
+	case *report.Writable:
+		fmt.Fprintf(out, "%s is up and writable (%dms)\n", report.Address, report.ResponseTimeMs)
+	default:
+		fmt.Fprintf(out, "%s is up but read-only (%dms)\n", report.Address, report.ResponseTimeMs)
+	}
+	return nil
+}
+
+// statusError returns an error when the instance is not healthy enough
+func statusError(report statusReport, checkWritable bool) error {
+	switch {
+	case report.Error != "":
+		return fmt.Errorf("server is unreachable: %s", report.Error)
+	case !report.Available:
+		return fmt.Errorf("server is not available")
+	case checkWritable && (report.Writable == nil || !*report.Writable):
+		return fmt.Errorf("server is not writable")
+	}
+	return nil
+}
+
+func init() {
+	StatusCmd.Flags().Bool("writable", false, "Also check that the instance accepts writes (GET /service/rest/v1/status/writable)")
+	StatusCmd.Flags().Bool("json", false, "Print the result as JSON")
+}
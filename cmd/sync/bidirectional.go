@@ -0,0 +1,256 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"nexus-util/nexus"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Conflict strategies accepted by --conflict
+const (
+	conflictNewest = "newest"
+	conflictSource = "source"
+	conflictTarget = "target"
+)
+
+// validateConflict checks the --conflict value; an empty value skips conflicts
+func validateConflict(conflict string) error {
+	switch conflict {
+	case "", conflictNewest, conflictSource, conflictTarget:
+		return nil
+	default:
+		return fmt.Errorf("invalid conflict strategy '%s': must be newest, source or target", conflict)
+	}
+}
+
+// endpoint is one side of a bidirectional sync
+type endpoint struct {
+	name   string
+	client *nexus.NexusClient
+	repo   string
+}
+
+// transfer copies file from one endpoint to the other
+type transfer struct {
+	file     nexus.Asset
+	from, to endpoint
+}
+
+// syncPlan is the result of comparing both repositories
+type syncPlan struct {
+	transfers []transfer
+	unchanged int
+	conflicts []string
+}
+
+// planBidirectional decides which files to copy in which direction. Files that
+// exist on one side only are copied to the other. Files whose content differs
+// are conflicts, because without a record of the previous sync it is unknown
+// which side changed; they are resolved with the conflict strategy or reported.
+func planBidirectional(ctx context.Context, source, target endpoint, sourceFiles, targetFiles []nexus.Asset, conflict string) (*syncPlan, error) {
+	sourceAssets := make(map[string]nexus.Asset, len(sourceFiles))
+	for _, file := range sourceFiles {
+		sourceAssets[file.Path] = file
+	}
+	targetAssets := make(map[string]nexus.Asset, len(targetFiles))
+	for _, file := range targetFiles {
+		targetAssets[file.Path] = file
+	}
+
+	plan := &syncPlan{}
+	for _, file := range sourceFiles {
+		other, ok := targetAssets[file.Path]
+		if !ok {
+			plan.transfers = append(plan.transfers, transfer{file: file, from: source, to: target})
+			continue
+		}
+
+		same, err := unchanged(ctx, source.client, target.client, source.repo, target.repo, file, other)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare '%s': %w", file.Path, err)
+		}
+		if same {
+			plan.unchanged++
+			continue
+		}
+
+		switch conflict {
+		case conflictSource:
+			plan.transfers = append(plan.transfers, transfer{file: file, from: source, to: target})
+		case conflictTarget:
+			plan.transfers = append(plan.transfers, transfer{file: other, from: target, to: source})
+		case conflictNewest:
+			sourceTime, err := lastModified(ctx, source, file)
+			if err != nil {
+				return nil, err
+			}
+			targetTime, err := lastModified(ctx, target, other)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case sourceTime.IsZero() || targetTime.IsZero() || sourceTime.Equal(targetTime):
+				plan.conflicts = append(plan.conflicts, file.Path)
+			case sourceTime.After(targetTime):
+				plan.transfers = append(plan.transfers, transfer{file: file, from: source, to: target})
+			default:
+				plan.transfers = append(plan.transfers, transfer{file: other, from: target, to: source})
+			}
+		default:
+			plan.conflicts = append(plan.conflicts, file.Path)
+		}
+	}
+
+	for _, file := range targetFiles {
+		if _, ok := sourceAssets[file.Path]; !ok {
+			plan.transfers = append(plan.transfers, transfer{file: file, from: target, to: source})
+		}
+	}
+
+	sort.Strings(plan.conflicts)
+	return plan, nil
+}
+
+// lastModified returns the modification time of file, asking the server with a
+// HEAD request when the search API did not report it. The zero time means unknown.
+func lastModified(ctx context.Context, side endpoint, file nexus.Asset) (time.Time, error) {
+	if file.LastModified != nil {
+		return *file.LastModified, nil
+	}
+	info, err := side.client.GetAssetInfo(ctx, side.repo, file.Path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last modification time of '%s' in %s: %w", file.Path, side.name, err)
+	}
+	if info.LastModified == nil {
+		return time.Time{}, nil
+	}
+	return *info.LastModified, nil
+}
+
+// bidirectionalOptions carries the sync flags that apply to a bidirectional run
+type bidirectionalOptions struct {
+	conflict        string
+	parallel        int
+	buffered        bool
+	showProgress    bool
+	events          *nexus.EventEmitter
+	continueOnError bool
+	failEmpty       bool
+	fileTimeout     time.Duration
+	deadline        time.Duration
+}
+
+// runBidirectional copies files missing on either side to the other side and
+// resolves files that differ according to opts.conflict. ctx carries the
+// opts.deadline budget set up by withDeadline from parent.
+func runBidirectional(parent, ctx context.Context, source, target endpoint, sourceFiles []nexus.Asset, opts bidirectionalOptions) error {
+	fmt.Fprintf(os.Stderr, "Scanning target repository '%s' on %s...\n", target.repo, target.client.BaseURL)
+	targetFiles, err := target.client.GetFilesInDirectory(ctx, target.repo, "")
+	if err != nil {
+		return fmt.Errorf("failed to get files from target repository: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Found %d files in target repository\n", len(targetFiles))
+	if opts.failEmpty && len(sourceFiles)+len(targetFiles) == 0 {
+		return fmt.Errorf("%w: source and target repositories are empty", nexus.ErrNoFiles)
+	}
+
+	plan, err := planBidirectional(ctx, source, target, sourceFiles, targetFiles, opts.conflict)
+	if err != nil {
+		return err
+	}
+	for _, path := range plan.conflicts {
+		source.client.Warnf("Conflict: '%s' differs between source and target, skipped (use --conflict newest|source|target)", path)
+	}
+
+	var toTarget, toSource, processed int64
+	total := len(plan.transfers)
+	var failures nexus.BatchErrors
+	opts.events.SetTotal(total)
+
+	g, gctx := errgroup.WithContext(ctx)
+	jobs := make(chan transfer)
+
+	g.Go(func() error {
+		defer close(jobs)
+		for _, job := range plan.transfers {
+			select {
+			case jobs <- job:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	for i := 0; i < opts.parallel; i++ {
+		g.Go(func() error {
+			for job := range jobs {
+				// Stop picking up new files once another worker has failed
+				if err := gctx.Err(); err != nil {
+					return err
+				}
+
+				n := atomic.AddInt64(&processed, 1)
+				if opts.showProgress {
+					fmt.Fprintf(os.Stderr, "[%d/%d] Copying %s (%s -> %s)\n", n, total, job.file.Path, job.from.name, job.to.name)
+				}
+				opts.events.Started(job.file.Path)
+
+				timedOut, err := transferWithTimeout(gctx, opts.fileTimeout, func(fileCtx context.Context) error {
+					if opts.buffered {
+						return job.from.client.TransferFile(fileCtx, job.to.client, job.from.repo, job.to.repo, job.file, false)
+					}
+					return job.from.client.TransferFileStream(fileCtx, job.to.client, job.from.repo, job.to.repo, job.file)
+				})
+				if err != nil {
+					// The file was cut off by --deadline and counts as not processed
+					if deadlineReached(parent, ctx) {
+						return ctx.Err()
+					}
+					opts.events.Failed(job.file.Path, err)
+					if timedOut {
+						job.from.client.Warnf("Copy of '%s' to %s timed out after %s, skipping", job.file.Path, job.to.name, opts.fileTimeout)
+						failures.Add(job.file.Path, fmt.Errorf("timed out after %s", opts.fileTimeout))
+						continue
+					}
+					if !opts.continueOnError {
+						return fmt.Errorf("failed to copy file '%s' to %s: %w", job.file.Path, job.to.name, err)
+					}
+					job.from.client.Errorf("Failed to copy '%s' to %s: %v", job.file.Path, job.to.name, err)
+					failures.Add(job.file.Path, err)
+					continue
+				}
+
+				opts.events.Completed(job.file.Path, assetBytes(job.file))
+				if job.to == target {
+					atomic.AddInt64(&toTarget, 1)
+				} else {
+					atomic.AddInt64(&toSource, 1)
+				}
+			}
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if deadlineReached(parent, ctx) {
+		notProcessed := total - int(toTarget) - int(toSource) - failures.Len()
+		fmt.Printf("\nSync stopped at deadline: %d files copied to target, %d files copied to source, %d files not processed\n", toTarget, toSource, notProcessed)
+		return errors.Join(fmt.Errorf("sync deadline of %s exceeded, %d of %d files not processed", opts.deadline, notProcessed, total), failures.Err("sync", total))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nSync completed: %d files copied to target, %d files copied to source, %d files unchanged, %d conflicts skipped\n",
+		toTarget, toSource, plan.unchanged, len(plan.conflicts))
+	return failures.Err("sync", total)
+}
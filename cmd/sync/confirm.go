@@ -0,0 +1,28 @@
+package sync
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// confirmDeletes lists the extraneous files about to be deleted from the
+// target and asks on in whether to delete them. Anything but yes, including
+// input that ends without an answer, declines.
+func confirmDeletes(targetRepo string, paths []string, in io.Reader, out io.Writer) bool {
+	fmt.Fprintf(out, "About to delete %d extraneous files from target repository '%s':\n", len(paths), targetRepo)
+	for _, filePath := range paths {
+		fmt.Fprintf(out, "  %s\n", filePath)
+	}
+	fmt.Fprint(out, "Delete them? [y/N]: ")
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		fmt.Fprintln(out)
+		return false
+	}
+}
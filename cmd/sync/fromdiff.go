@@ -0,0 +1,114 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"nexus-util/nexus"
+)
+
+// diffPlan is the part of an 'asset diff' result that sync --from-diff acts on.
+// The lists are pointers so that a file which is not a diff result can be told
+// apart from a diff without differences.
+type diffPlan struct {
+	OnlySource *[]string `json:"only_source"`
+	OnlyTarget *[]string `json:"only_target"`
+	Different  *[]struct {
+		Path string `json:"path"`
+	} `json:"different"`
+	Comparison *struct {
+		Source diffPlanSide `json:"source"`
+		Target diffPlanSide `json:"target"`
+	} `json:"comparison"`
+}
+
+// diffPlanSide is one side of the comparison block of a diff result
+type diffPlanSide struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+}
+
+// loadDiffPlan reads a diff result written by 'asset diff' and checks that it
+// compared sourceRepo with targetRepo
+func loadDiffPlan(fileName, sourceRepo, targetRepo string) (*diffPlan, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading diff: %w", err)
+	}
+
+	var plan diffPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("error parsing diff '%s': %w", fileName, err)
+	}
+	if plan.OnlySource == nil || plan.OnlyTarget == nil || plan.Different == nil {
+		return nil, fmt.Errorf("'%s' is not a diff result: only_source, only_target and different are required", fileName)
+	}
+
+	// Diffs written before the comparison block was added are taken at face value
+	if plan.Comparison != nil {
+		for _, side := range []struct {
+			name string
+			got  diffPlanSide
+			repo string
+		}{
+			{"source", plan.Comparison.Source, sourceRepo},
+			{"target", plan.Comparison.Target, targetRepo},
+		} {
+			if side.got.Type != "nexus" {
+				return nil, fmt.Errorf("the diff %s is a %s, only diffs between two repositories can be synced", side.name, side.got.Type)
+			}
+			if side.got.Repository != side.repo {
+				return nil, fmt.Errorf("the diff %s repository is '%s', not '%s'", side.name, side.got.Repository, side.repo)
+			}
+		}
+	}
+
+	for _, relPath := range append(plan.transferPaths(), *plan.OnlyTarget...) {
+		if relPath == "" || strings.HasPrefix(relPath, "/") || path.Clean(relPath) != relPath || strings.HasPrefix(relPath, "../") {
+			return nil, fmt.Errorf("invalid path '%s' in diff '%s'", relPath, fileName)
+		}
+	}
+
+	return &plan, nil
+}
+
+// root returns the repository path the diff paths are relative to
+func (p *diffPlan) root() string {
+	if p.Comparison == nil {
+		return ""
+	}
+	return strings.Trim(p.Comparison.Source.Path, "/")
+}
+
+// transferPaths lists the relative paths of files missing from or different
+// in the target
+func (p *diffPlan) transferPaths() []string {
+	paths := append([]string{}, *p.OnlySource...)
+	for _, file := range *p.Different {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
+
+// transfers returns the source assets to copy to the target
+func (p *diffPlan) transfers(client *nexus.NexusClient, repository string) []nexus.Asset {
+	paths := p.transferPaths()
+	assets := make([]nexus.Asset, 0, len(paths))
+	for _, relPath := range paths {
+		assets = append(assets, client.AssetAt(repository, path.Join(p.root(), relPath)))
+	}
+	return assets
+}
+
+// extraneous returns the repository paths of files that exist only in the target
+func (p *diffPlan) extraneous() []string {
+	paths := make([]string, 0, len(*p.OnlyTarget))
+	for _, relPath := range *p.OnlyTarget {
+		paths = append(paths, path.Join(p.root(), relPath))
+	}
+	return paths
+}
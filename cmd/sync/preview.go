@@ -0,0 +1,198 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	gosync "sync"
+
+	"nexus-util/nexus"
+)
+
+// Reasons a source file is not transferred, as reported in progress events
+// and the --dry plan
+const (
+	reasonExists              = "exists"
+	reasonUnchanged           = "unchanged"
+	reasonUnchangedSinceState = "unchanged since last sync"
+)
+
+// skipDescriptions are the reasons as shown in the text output
+var skipDescriptions = map[string]string{
+	reasonExists:              "already exists",
+	reasonUnchanged:           "unchanged",
+	reasonUnchangedSinceState: "unchanged since last sync",
+}
+
+// Formats of the --dry plan accepted by --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// validateOutput checks the --output value
+func validateOutput(output string) error {
+	switch output {
+	case outputText, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format '%s': must be text or json", output)
+	}
+}
+
+// skipCheck decides which source files the target already holds, as
+// --skip-unchanged and --skip-existing ask
+type skipCheck struct {
+	sourceClient, targetClient *nexus.NexusClient
+	sourceRepo, targetRepo     string
+	unchanged                  bool
+	existing                   bool
+	// targetAssets indexes the target files when unchanged is set
+	targetAssets map[string]nexus.Asset
+}
+
+// reason returns why file need not be transferred, or "" when it must be.
+// A check that fails is logged and the file is transferred.
+func (s *skipCheck) reason(ctx context.Context, file nexus.Asset) string {
+	if s.unchanged {
+		target, ok := s.targetAssets[file.Path]
+		if !ok {
+			return ""
+		}
+		same, err := unchanged(ctx, s.sourceClient, s.targetClient, s.sourceRepo, s.targetRepo, file, target)
+		if err != nil {
+			s.sourceClient.Warnf("failed to compare %s with target: %v", file.Path, err)
+			return ""
+		}
+		if same {
+			return reasonUnchanged
+		}
+		return ""
+	}
+	if s.existing {
+		exists, err := s.targetClient.FileExists(ctx, s.targetRepo, file.Path)
+		if err != nil {
+			s.sourceClient.Warnf("failed to check if file exists in target: %v", err)
+			return ""
+		}
+		if exists {
+			return reasonExists
+		}
+	}
+	return ""
+}
+
+// dryRunPlan is what a sync would do, printed by --dry instead of transferring
+type dryRunPlan struct {
+	Transfer []string      `json:"transfer"`
+	Skip     []skippedFile `json:"skip"`
+	// Delete is only computed with --delete-extraneous, so that it can be told
+	// apart from a mirror without extraneous files
+	Delete *[]string `json:"delete,omitempty"`
+}
+
+// skippedFile is a source file the sync would not transfer
+type skippedFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// previewTransfers runs the skip checks of a real sync over files, with
+// parallel workers, and sorts each file into the transfers or skips of the
+// plan. Files unchanged since the last sync are skipped without a check.
+func previewTransfers(ctx context.Context, skip *skipCheck, files, unchangedSinceState []nexus.Asset, parallel int) *dryRunPlan {
+	reasons := make([]string, len(files))
+	indexes := make(chan int)
+	var wg gosync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				reasons[index] = skip.reason(ctx, files[index])
+			}
+		}()
+	}
+	for index := range files {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	plan := &dryRunPlan{Transfer: []string{}, Skip: []skippedFile{}}
+	for _, file := range unchangedSinceState {
+		plan.Skip = append(plan.Skip, skippedFile{Path: file.Path, Reason: reasonUnchangedSinceState})
+	}
+	for i, file := range files {
+		if reasons[i] != "" {
+			plan.Skip = append(plan.Skip, skippedFile{Path: file.Path, Reason: reasons[i]})
+		} else {
+			plan.Transfer = append(plan.Transfer, file.Path)
+		}
+	}
+	return plan
+}
+
+// write prints the plan as a summary line followed by the files of each
+// action, or as one JSON object
+func (p *dryRunPlan) write(out io.Writer, output string) error {
+	if output == outputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	}
+
+	summary := fmt.Sprintf("Dry run: %d files to transfer, %d files to skip", len(p.Transfer), len(p.Skip))
+	if p.Delete != nil {
+		summary += fmt.Sprintf(", %d files to delete", len(*p.Delete))
+	}
+	fmt.Fprintln(out, summary)
+
+	if len(p.Transfer) > 0 {
+		fmt.Fprintln(out, "\nTransfer:")
+		for _, filePath := range p.Transfer {
+			fmt.Fprintf(out, "  %s\n", filePath)
+		}
+	}
+	if len(p.Skip) > 0 {
+		fmt.Fprintln(out, "\nSkip:")
+		for _, file := range p.Skip {
+			fmt.Fprintf(out, "  %s (%s)\n", file.Path, skipDescriptions[file.Reason])
+		}
+	}
+	if p.Delete != nil && len(*p.Delete) > 0 {
+		fmt.Fprintln(out, "\nDelete:")
+		for _, filePath := range *p.Delete {
+			fmt.Fprintf(out, "  %s\n", filePath)
+		}
+	}
+	return nil
+}
+
+// findExtraneous returns the target files that are not in the source, as
+// listed by the diff when syncing from one
+func findExtraneous(ctx context.Context, targetClient *nexus.NexusClient, targetRepo, targetAddress string, sourceFiles []nexus.Asset, plan *diffPlan) ([]string, error) {
+	if plan != nil {
+		return plan.extraneous(), nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Scanning target repository '%s' on %s for extraneous files...\n", targetRepo, targetAddress)
+	targetFiles, err := targetClient.GetFilesInDirectory(ctx, targetRepo, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files from target repository: %w", err)
+	}
+
+	sourcePaths := make(map[string]struct{}, len(sourceFiles))
+	for _, file := range sourceFiles {
+		sourcePaths[file.Path] = struct{}{}
+	}
+	var extraneousPaths []string
+	for _, file := range targetFiles {
+		if _, ok := sourcePaths[file.Path]; !ok {
+			extraneousPaths = append(extraneousPaths, file.Path)
+		}
+	}
+	return extraneousPaths, nil
+}
@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nexus-util/nexus"
+)
+
+// syncStateVersion is the format version written to --state-file
+const syncStateVersion = 1
+
+// syncState is the content of a --state-file: for each source repository, the
+// files the last sync left identical on its target
+type syncState struct {
+	Version int                     `json:"version"`
+	Sources map[string]*sourceState `json:"sources"`
+}
+
+// sourceState records the files of one source repository synced to a target
+type sourceState struct {
+	Target   string    `json:"target"`
+	SyncedAt time.Time `json:"synced_at"`
+	// Files maps the path of every synced file to its strongest checksum as
+	// "algorithm:value"
+	Files map[string]string `json:"files"`
+}
+
+// repositoryKey identifies a repository on a server in the state file
+func repositoryKey(address, repository string) string {
+	return strings.TrimRight(address, "/") + "/repository/" + repository
+}
+
+// loadSyncState reads a state file; a file that does not exist yet is an empty state
+func loadSyncState(fileName string) (*syncState, error) {
+	state := &syncState{Version: syncStateVersion, Sources: map[string]*sourceState{}}
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("error parsing state file '%s': %w", fileName, err)
+	}
+	if state.Version != syncStateVersion {
+		return nil, fmt.Errorf("state file '%s' has unsupported version %d", fileName, state.Version)
+	}
+	if state.Sources == nil {
+		state.Sources = map[string]*sourceState{}
+	}
+	return state, nil
+}
+
+// pending splits files into those that changed since the last sync of source
+// to target and those that did not. Files without a checksum always count as
+// changed, and so do all files when the last sync of source went to another target.
+func (s *syncState) pending(source, target string, files []nexus.Asset) (changed []nexus.Asset, unchanged []nexus.Asset) {
+	previous := s.Sources[source]
+	if previous == nil || previous.Target != target {
+		return files, nil
+	}
+	for _, file := range files {
+		fingerprint := fileFingerprint(file)
+		if fingerprint != "" && previous.Files[file.Path] == fingerprint {
+			unchanged = append(unchanged, file)
+		} else {
+			changed = append(changed, file)
+		}
+	}
+	return changed, unchanged
+}
+
+// record replaces the state of source with the synced files
+func (s *syncState) record(source, target string, synced []nexus.Asset, now time.Time) {
+	files := make(map[string]string, len(synced))
+	for _, file := range synced {
+		if fingerprint := fileFingerprint(file); fingerprint != "" {
+			files[file.Path] = fingerprint
+		}
+	}
+	s.Sources[source] = &sourceState{Target: target, SyncedAt: now.UTC(), Files: files}
+}
+
+// writeFile writes the state to fileName atomically: it is written to a
+// temporary file in the same directory and renamed into place, so an
+// interrupted sync never leaves a partial state file behind
+func (s *syncState) writeFile(fileName string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fileName), "."+filepath.Base(fileName)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fileName); err != nil {
+		return fmt.Errorf("failed to move state file into place: %w", err)
+	}
+	return nil
+}
+
+// fileFingerprint returns the strongest checksum the search API reported for a
+// file as "algorithm:value", or "" when it reported none
+func fileFingerprint(file nexus.Asset) string {
+	checksums := nexus.NormalizeChecksums(file.Checksum)
+	for _, algorithm := range nexus.ChecksumPreference {
+		if value := checksums[algorithm]; value != "" {
+			return algorithm + ":" + value
+		}
+	}
+	return ""
+}
@@ -1,12 +1,20 @@
 package sync
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	gosync "sync"
+	"sync/atomic"
+	"time"
 
 	"nexus-util/config"
 	"nexus-util/nexus"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var SyncCmd = &cobra.Command{
@@ -14,6 +22,8 @@ var SyncCmd = &cobra.Command{
 	Short: "Transfer contents from one Nexus repository to another",
 	Long: `Transfer contents from one Nexus server/repository to another Nexus server/repository.
 This command downloads files from the source repository and uploads them to the target repository.
+Files are streamed from source to target without being held in memory; use --buffered to
+load each file into memory first (allows retrying failed uploads).
 
 Examples:
   # Transfer entire repository from one server to another
@@ -25,14 +35,77 @@ Examples:
                    --target-address http://target.example.com --target-repo myrepo \
                    --skip-existing --show-progress
 
+  # Transfer 8 files at a time
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --parallel 8
+
+  # Limit the total transfer bandwidth to 10 MB/s
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --parallel 4 --max-rate 10MB
+
+  # Update files whose content changed, comparing checksums
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --skip-unchanged
+
+  # Mirror the source: also delete target files that are not in the source,
+  # after listing them and asking for confirmation (--force does not ask)
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --delete-extraneous
+
+  # Skip files that take longer than 10 minutes and stop the whole run after 2 hours
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --file-timeout 10m --deadline 2h
+
+  # Exit with code 2 in a scheduled job when the source repository is empty
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --fail-empty
+
+  # Keep two mirrors in step: copy files missing on either side to the other,
+  # resolving files that differ in favour of the most recently modified copy
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --bidirectional --conflict newest
+
+  # Apply a diff between the two repositories without scanning them again:
+  # copy missing and different files, delete files only in the target
+  nexus-util asset diff -a http://source.example.com -r myrepo \
+                   --target-address http://target.example.com --target-repo myrepo > drift.json
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --from-diff drift.json --delete-extraneous
+
+  # Preview a mirror run as JSON: the files it would transfer, skip and delete
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --delete-extraneous --dry --output json
+
+  # Only transfer files added or changed since the previous run
+  nexus-util sync --source-address http://source.example.com --source-repo myrepo \
+                   --target-address http://target.example.com --target-repo myrepo \
+                   --state-file mirror-state.json
+
   # Use config for one or both servers
   nexus-util sync --source-repo myrepo \
                    --target-address http://target.example.com --target-repo myrepo
 
+  # Sync between two servers defined as profiles in the config file
+  nexus-util sync --source-profile dev --source-repo myrepo \
+                   --target-profile prod --target-repo myrepo
+
   # Sync with authentication
   nexus-util sync --source-address http://source.example.com --source-user user1 --source-pass pass1 \
                    --source-repo repo1 --target-address http://target.example.com --target-user user2 \
-                   --target-pass pass2 --target-repo repo1`,
+                   --target-pass pass2 --target-repo repo1
+
+  # Sync with bearer token authentication
+  nexus-util sync --source-address http://source.example.com --source-token token1 --source-repo repo1 \
+                   --target-address http://target.example.com --target-token token2 --target-repo repo1`,
 	RunE: runSync,
 }
 
@@ -42,26 +115,115 @@ func runSync(cmd *cobra.Command, args []string) error {
 	sourceRepo, _ := cmd.Flags().GetString("source-repo")
 	sourceUser, _ := cmd.Flags().GetString("source-user")
 	sourcePassword, _ := cmd.Flags().GetString("source-pass")
+	sourceToken, _ := cmd.Flags().GetString("source-token")
+	sourceProfile, _ := cmd.Flags().GetString("source-profile")
+	sourceBasePath, _ := cmd.Flags().GetString("source-base-path")
 
 	// Get target flags
 	targetAddress, _ := cmd.Flags().GetString("target-address")
 	targetRepo, _ := cmd.Flags().GetString("target-repo")
 	targetUser, _ := cmd.Flags().GetString("target-user")
 	targetPassword, _ := cmd.Flags().GetString("target-pass")
+	targetToken, _ := cmd.Flags().GetString("target-token")
+	targetProfile, _ := cmd.Flags().GetString("target-profile")
+	targetBasePath, _ := cmd.Flags().GetString("target-base-path")
 
 	// Common flags
 	configPath, _ := cmd.Flags().GetString("config")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	dryRun, _ := cmd.Flags().GetBool("dry")
 	insecure, _ := cmd.Flags().GetBool("insecure")
+	clientCert, _ := cmd.Flags().GetString("client-cert")
+	clientKey, _ := cmd.Flags().GetString("client-key")
+	caBundle, _ := cmd.Flags().GetString("ca-bundle")
+	proxy, _ := cmd.Flags().GetString("proxy")
+	userAgent, _ := cmd.Flags().GetString("user-agent")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
 	skipExisting, _ := cmd.Flags().GetBool("skip-existing")
 	showProgress, _ := cmd.Flags().GetBool("show-progress")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	buffered, _ := cmd.Flags().GetBool("buffered")
+	maxBufferSize, _ := cmd.Flags().GetString("max-buffer-size")
+	deleteExtraneous, _ := cmd.Flags().GetBool("delete-extraneous")
+	skipUnchanged, _ := cmd.Flags().GetBool("skip-unchanged")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+	maxRate, _ := cmd.Flags().GetString("max-rate")
+	bidirectional, _ := cmd.Flags().GetBool("bidirectional")
+	conflict, _ := cmd.Flags().GetString("conflict")
+	failEmpty, _ := cmd.Flags().GetBool("fail-empty")
+	fileTimeout, _ := cmd.Flags().GetDuration("file-timeout")
+	deadline, _ := cmd.Flags().GetDuration("deadline")
+	fromDiff, _ := cmd.Flags().GetString("from-diff")
+	stateFile, _ := cmd.Flags().GetString("state-file")
+	progressFormat, _ := cmd.Flags().GetString("progress-format")
+	output, _ := cmd.Flags().GetString("output")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if err := validateConflict(conflict); err != nil {
+		return err
+	}
+	if conflict != "" && !bidirectional {
+		return fmt.Errorf("--conflict requires --bidirectional")
+	}
+	if bidirectional && deleteExtraneous {
+		return fmt.Errorf("--bidirectional cannot be combined with --delete-extraneous")
+	}
+	if fileTimeout < 0 || deadline < 0 {
+		return fmt.Errorf("--file-timeout and --deadline must not be negative")
+	}
+	if fromDiff != "" && (bidirectional || skipUnchanged) {
+		return fmt.Errorf("--from-diff cannot be combined with --bidirectional or --skip-unchanged")
+	}
+	if stateFile != "" && (fromDiff != "" || bidirectional) {
+		return fmt.Errorf("--state-file cannot be combined with --from-diff or --bidirectional")
+	}
+	if err := nexus.ValidateProgressFormat(progressFormat); err != nil {
+		return err
+	}
+	if err := validateOutput(output); err != nil {
+		return err
+	}
+	// JSON events replace the progress lines
+	var events *nexus.EventEmitter
+	if progressFormat == nexus.ProgressFormatJSON {
+		events = nexus.NewEventEmitter(os.Stderr)
+		showProgress = false
+	}
+
+	// --deadline bounds the whole run; files not reached in time are skipped
+	ctx, cancel := withDeadline(cmd.Context(), deadline)
+	defer cancel()
+
+	// Each side falls back to the global profile
+	if sourceProfile == "" {
+		sourceProfile = profile
+	}
+	if targetProfile == "" {
+		targetProfile = profile
+	}
+	if sourceBasePath == "" {
+		sourceBasePath = basePath
+	}
+	if targetBasePath == "" {
+		targetBasePath = basePath
+	}
 
 	// Load configuration for fallback values
 	sourceConfig, err := config.LoadConfigWithFlags(configPath, map[string]interface{}{
 		"nexusAddress": sourceAddress,
 		"user":         sourceUser,
 		"password":     sourcePassword,
+		"token":        sourceToken,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     sourceBasePath,
+		"timeout":      timeout,
+		"profile":      sourceProfile,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading source configuration: %w", err)
@@ -71,10 +233,22 @@ func runSync(cmd *cobra.Command, args []string) error {
 		"nexusAddress": targetAddress,
 		"user":         targetUser,
 		"password":     targetPassword,
+		"token":        targetToken,
+		"clientCert":   clientCert,
+		"clientKey":    clientKey,
+		"caBundle":     caBundle,
+		"proxy":        proxy,
+		"userAgent":    userAgent,
+		"basePath":     targetBasePath,
+		"timeout":      timeout,
+		"profile":      targetProfile,
 	})
 	if err != nil {
 		return fmt.Errorf("error loading target configuration: %w", err)
 	}
+	if err := validateTimeouts(sourceConfig, targetConfig); err != nil {
+		return err
+	}
 
 	// Determine source and target addresses
 	finalSourceAddress := sourceAddress
@@ -101,6 +275,24 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("target repository is required")
 	}
 
+	// Read the diff before connecting so a bad file fails fast
+	var plan *diffPlan
+	if fromDiff != "" {
+		plan, err = loadDiffPlan(fromDiff, sourceRepo, targetRepo)
+		if err != nil {
+			return err
+		}
+		// The diff already decided which files differ
+		skipExisting = false
+	}
+	var state *syncState
+	if stateFile != "" {
+		state, err = loadSyncState(stateFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Get credentials - prefer command line over config for each side
 	sourceUsername := sourceUser
 	if sourceUsername == "" {
@@ -122,80 +314,417 @@ func runSync(cmd *cobra.Command, args []string) error {
 		targetPass = targetConfig.GetPassword()
 	}
 
+	sourceAuthToken := sourceToken
+	if sourceAuthToken == "" {
+		sourceAuthToken = sourceConfig.GetToken()
+	}
+
+	targetAuthToken := targetToken
+	if targetAuthToken == "" {
+		targetAuthToken = targetConfig.GetToken()
+	}
+
+	// A context path only applies to its own side
+	sourceContextPath := sourceBasePath
+	if sourceContextPath == "" {
+		sourceContextPath = sourceConfig.GetBasePath()
+	}
+
+	targetContextPath := targetBasePath
+	if targetContextPath == "" {
+		targetContextPath = targetConfig.GetBasePath()
+	}
+
+	// Both clients draw from one connection pool, sized for the parallel workers,
+	// unless their profiles need different TLS or proxy settings
+	sourceTLS := nexus.TLSOptions{
+		ClientCertFile: sourceConfig.GetClientCert(),
+		ClientKeyFile:  sourceConfig.GetClientKey(),
+		CABundleFile:   sourceConfig.GetCABundle(),
+	}
+	targetTLS := nexus.TLSOptions{
+		ClientCertFile: targetConfig.GetClientCert(),
+		ClientKeyFile:  targetConfig.GetClientKey(),
+		CABundleFile:   targetConfig.GetCABundle(),
+	}
+	var sourceTransport, targetTransport *http.Transport
+	if sourceTLS.Equal(targetTLS) && sourceConfig.GetProxy() == targetConfig.GetProxy() {
+		// Each worker holds a download and an upload connection
+		sourceTransport = nexus.NewTransport(insecure, 2*parallel)
+		targetTransport = sourceTransport
+	} else {
+		sourceTransport = nexus.NewTransport(insecure, parallel)
+		targetTransport = nexus.NewTransport(insecure, parallel)
+	}
+
 	// Create clients
-	sourceClient := nexus.NewNexusClient(finalSourceAddress, sourceUsername, sourcePass, quiet, dryRun, insecure)
-	targetClient := nexus.NewNexusClient(finalTargetAddress, targetUsername, targetPass, quiet, dryRun, insecure)
+	sourceClient := nexus.NewNexusClientWithTransport(finalSourceAddress, sourceUsername, sourcePass, quiet, dryRun, insecure, sourceTransport)
+	if err := sourceClient.ConfigureTLS(sourceTLS); err != nil {
+		return fmt.Errorf("error creating source client: %w", err)
+	}
+	sourceClient.Token = sourceAuthToken
+	sourceClient.UserAgent = sourceConfig.GetUserAgent()
+	sourceClient.BasePath = sourceContextPath
+	if err := sourceClient.SetProxy(sourceConfig.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring source proxy: %w", err)
+	}
+	if timeout, ok := sourceConfig.GetTimeout(); ok {
+		sourceClient.SetTimeout(timeout)
+	}
+	targetClient := nexus.NewNexusClientWithTransport(finalTargetAddress, targetUsername, targetPass, quiet, dryRun, insecure, targetTransport)
+	if err := targetClient.ConfigureTLS(targetTLS); err != nil {
+		return fmt.Errorf("error creating target client: %w", err)
+	}
+	targetClient.Token = targetAuthToken
+	targetClient.UserAgent = targetConfig.GetUserAgent()
+	targetClient.BasePath = targetContextPath
+	if err := targetClient.SetProxy(targetConfig.GetProxy()); err != nil {
+		return fmt.Errorf("error configuring target proxy: %w", err)
+	}
+	if timeout, ok := targetConfig.GetTimeout(); ok {
+		targetClient.SetTimeout(timeout)
+	}
 
-	// Get all files from source repository
-	fmt.Printf("Scanning source repository '%s' on %s...\n", sourceRepo, finalSourceAddress)
-	sourceFiles, err := sourceClient.GetFilesInDirectory(sourceRepo, "")
+	// One limiter caps the total bandwidth of all parallel transfers
+	rate, err := nexus.ParseRate(maxRate)
 	if err != nil {
-		return fmt.Errorf("failed to get files from source repository: %w", err)
+		return err
 	}
+	limiter := nexus.NewRateLimiter(rate)
+	sourceClient.RateLimit = limiter
+	targetClient.RateLimit = limiter
 
-	if len(sourceFiles) == 0 {
-		fmt.Println("No files found in source repository")
+	// Buffered transfers fail instead of loading files above the limit into memory
+	bufferLimit, err := nexus.ParseSize(maxBufferSize)
+	if err != nil {
+		return err
+	}
+	sourceClient.MaxBufferSize = bufferLimit
+	targetClient.MaxBufferSize = bufferLimit
+
+	// Get all files from source repository, or only those the diff found out of date
+	var sourceFiles []nexus.Asset
+	if plan != nil {
+		sourceFiles = plan.transfers(sourceClient, sourceRepo)
+		fmt.Fprintf(os.Stderr, "Diff '%s' lists %d files to transfer and %d extraneous files\n", fromDiff, len(sourceFiles), len(*plan.OnlyTarget))
+		if len(*plan.OnlyTarget) > 0 && !deleteExtraneous {
+			fmt.Fprintln(os.Stderr, "Extraneous files are kept, use --delete-extraneous to delete them")
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Scanning source repository '%s' on %s...\n", sourceRepo, finalSourceAddress)
+		sourceFiles, err = sourceClient.GetFilesInDirectory(ctx, sourceRepo, "")
+		if err != nil {
+			return fmt.Errorf("failed to get files from source repository: %w", err)
+		}
+	}
+
+	if len(sourceFiles) == 0 && !deleteExtraneous && !bidirectional {
+		if plan != nil {
+			fmt.Fprintln(os.Stderr, "No files to transfer")
+			if failEmpty {
+				return fmt.Errorf("%w: diff '%s' lists no files to transfer", nexus.ErrNoFiles, fromDiff)
+			}
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, "No files found in source repository")
+		if failEmpty {
+			return fmt.Errorf("%w: source repository '%s' is empty", nexus.ErrNoFiles, sourceRepo)
+		}
 		return nil
 	}
 
-	fmt.Printf("Found %d files in source repository\n", len(sourceFiles))
+	if plan == nil {
+		fmt.Fprintf(os.Stderr, "Found %d files in source repository\n", len(sourceFiles))
+	}
+
+	// Files whose checksum is unchanged since the last sync are not transferred again
+	sourceKey := repositoryKey(finalSourceAddress, sourceRepo)
+	targetKey := repositoryKey(finalTargetAddress, targetRepo)
+	transfers := sourceFiles
+	var unchangedSinceState []nexus.Asset
+	if state != nil {
+		transfers, unchangedSinceState = state.pending(sourceKey, targetKey, sourceFiles)
+		fmt.Fprintf(os.Stderr, "State '%s': %d of %d files changed since the last sync\n", stateFile, len(transfers), len(sourceFiles))
+	}
+
+	// Transfer files using a pool of workers
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	if bidirectional {
+		source := endpoint{name: "source", client: sourceClient, repo: sourceRepo}
+		target := endpoint{name: "target", client: targetClient, repo: targetRepo}
+		return runBidirectional(cmd.Context(), ctx, source, target, sourceFiles, bidirectionalOptions{
+			conflict:        conflict,
+			parallel:        parallel,
+			buffered:        buffered,
+			showProgress:    showProgress,
+			events:          events,
+			continueOnError: continueOnError,
+			failEmpty:       failEmpty,
+			fileTimeout:     fileTimeout,
+			deadline:        deadline,
+		})
+	}
+
+	// Index target files so unchanged files can be detected without extra requests
+	skip := &skipCheck{
+		sourceClient: sourceClient,
+		targetClient: targetClient,
+		sourceRepo:   sourceRepo,
+		targetRepo:   targetRepo,
+		unchanged:    skipUnchanged,
+		existing:     skipExisting,
+	}
+	if skipUnchanged {
+		targetFiles, err := targetClient.GetFilesInDirectory(ctx, targetRepo, "")
+		if err != nil {
+			return fmt.Errorf("failed to get files from target repository: %w", err)
+		}
+		skip.targetAssets = make(map[string]nexus.Asset, len(targetFiles))
+		for _, file := range targetFiles {
+			skip.targetAssets[file.Path] = file
+		}
+	}
 
-	// Check disk space for largest file if not dry run
-	if !dryRun {
-		// Find the largest file
-		maxSize := int64(0)
-		var largestFile nexus.Asset
-		for _, file := range sourceFiles {
-			size, err := sourceClient.GetFileSize(sourceRepo, file.Path)
+	// A dry run prints the plan the checks above lead to instead of transferring
+	if dryRun {
+		preview := previewTransfers(ctx, skip, transfers, unchangedSinceState, parallel)
+		if deleteExtraneous {
+			extraneousPaths, err := findExtraneous(ctx, targetClient, targetRepo, finalTargetAddress, sourceFiles, plan)
 			if err != nil {
-				// Log but continue
-				sourceClient.Logf("Warning: failed to get size for %s: %v", file, err)
-				continue
-			}
-			if size > maxSize {
-				maxSize = size
-				largestFile = file
+				return err
 			}
+			extraneousPaths = append([]string{}, extraneousPaths...)
+			preview.Delete = &extraneousPaths
 		}
+		return preview.write(os.Stdout, output)
+	}
 
-		if maxSize > 0 {
-			fmt.Printf("Largest file: %s (%d bytes)\n", largestFile.Path, maxSize)
-			fmt.Println("Disk space check passed")
+	// Ask before deleting extraneous files, up front so the transfers can run
+	// unattended. Declining keeps them but still transfers.
+	var extraneousPaths []string
+	deleteConfirmed := true
+	if deleteExtraneous {
+		extraneousPaths, err = findExtraneous(ctx, targetClient, targetRepo, finalTargetAddress, sourceFiles, plan)
+		if err != nil {
+			return err
+		}
+		if len(extraneousPaths) > 0 && !force {
+			deleteConfirmed = confirmDeletes(targetRepo, extraneousPaths, cmd.InOrStdin(), os.Stderr)
 		}
 	}
 
-	// Transfer files
-	transferred := 0
-	skipped := 0
+	var transferred, skipped, processed int64
+	total := len(sourceFiles)
+	var failures nexus.BatchErrors
+	skipped = int64(len(unchangedSinceState))
+	processed = skipped
+	events.SetTotal(total)
+	for _, file := range unchangedSinceState {
+		events.Skipped(file.Path, reasonUnchangedSinceState)
+	}
 
-	for i, file := range sourceFiles {
-		if showProgress {
-			fmt.Printf("[%d/%d] Processing: %s\n", i+1, len(sourceFiles), file.Path)
+	// synced collects the files the target holds afterwards, for the state file
+	var syncedMu gosync.Mutex
+	synced := append([]nexus.Asset(nil), unchangedSinceState...)
+	markSynced := func(file nexus.Asset) {
+		syncedMu.Lock()
+		synced = append(synced, file)
+		syncedMu.Unlock()
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	jobs := make(chan nexus.Asset)
+
+	g.Go(func() error {
+		defer close(jobs)
+		for _, file := range transfers {
+			select {
+			case jobs <- file:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
 		}
+		return nil
+	})
 
-		// Check if file should be skipped
-		if skipExisting {
-			exists, err := targetClient.FileExists(targetRepo, file.Path)
-			if err != nil {
-				sourceClient.Logf("Warning: failed to check if file exists in target: %v", err)
-			} else if exists {
+	for i := 0; i < parallel; i++ {
+		g.Go(func() error {
+			for file := range jobs {
+				// Stop picking up new files once another worker has failed
+				if err := gctx.Err(); err != nil {
+					return err
+				}
+
+				n := atomic.AddInt64(&processed, 1)
 				if showProgress {
-					fmt.Printf("  Skipped (already exists)\n")
+					fmt.Fprintf(os.Stderr, "[%d/%d] Processing: %s\n", n, total, file.Path)
+				}
+
+				// Check if file should be skipped
+				if reason := skip.reason(gctx, file); reason != "" {
+					if showProgress {
+						fmt.Fprintf(os.Stderr, "  Skipped %s (%s)\n", file.Path, skipDescriptions[reason])
+					}
+					events.Skipped(file.Path, reason)
+					atomic.AddInt64(&skipped, 1)
+					markSynced(file)
+					continue
 				}
-				skipped++
-				continue
+
+				events.Started(file.Path)
+				timedOut, err := transferWithTimeout(gctx, fileTimeout, func(fileCtx context.Context) error {
+					if buffered {
+						return sourceClient.TransferFile(fileCtx, targetClient, sourceRepo, targetRepo, file, false)
+					}
+					return sourceClient.TransferFileStream(fileCtx, targetClient, sourceRepo, targetRepo, file)
+				})
+				if err != nil {
+					// The file was cut off by --deadline and counts as not processed
+					if deadlineReached(cmd.Context(), ctx) {
+						return ctx.Err()
+					}
+					events.Failed(file.Path, err)
+					if timedOut {
+						sourceClient.Warnf("Transfer of '%s' timed out after %s, skipping", file.Path, fileTimeout)
+						failures.Add(file.Path, fmt.Errorf("timed out after %s", fileTimeout))
+						continue
+					}
+					if !continueOnError {
+						return fmt.Errorf("failed to transfer file '%s': %w", file.Path, err)
+					}
+					sourceClient.Errorf("Failed to transfer '%s': %v", file.Path, err)
+					failures.Add(file.Path, err)
+					continue
+				}
+
+				atomic.AddInt64(&transferred, 1)
+				markSynced(file)
+				events.Completed(file.Path, assetBytes(file))
 			}
-		}
+			return nil
+		})
+	}
 
-		err := sourceClient.TransferFile(targetClient, sourceRepo, targetRepo, file, false)
-		if err != nil {
-			return fmt.Errorf("failed to transfer file '%s': %w", file, err)
+	err = g.Wait()
+
+	// Record what was synced even when the run failed or was interrupted, so the
+	// next run continues from here
+	var stateErr error
+	if state != nil {
+		state.record(sourceKey, targetKey, synced, time.Now())
+		stateErr = state.writeFile(stateFile)
+	}
+	if deadlineReached(cmd.Context(), ctx) {
+		notProcessed := total - int(transferred) - int(skipped) - failures.Len()
+		fmt.Printf("\nSync stopped at deadline: %d files transferred, %d files skipped, %d files not processed\n", transferred, skipped, notProcessed)
+		return errors.Join(fmt.Errorf("sync deadline of %s exceeded, %d of %d files not processed", deadline, notProcessed, total), failures.Err("transfer", total), stateErr)
+	}
+	if err := errors.Join(err, stateErr); err != nil {
+		return err
+	}
+
+	if !deleteExtraneous {
+		fmt.Printf("\nTransfer completed: %d files transferred, %d files skipped\n", transferred, skipped)
+		return failures.Err("transfer", total)
+	}
+
+	extraneous := len(extraneousPaths)
+	if !deleteConfirmed {
+		fmt.Printf("\nTransfer completed: %d files transferred, %d files skipped, 0 files deleted (%d extraneous files kept, deletion not confirmed)\n", transferred, skipped, extraneous)
+		return failures.Err("transfer", total)
+	}
+
+	// Remove target files that no longer exist in the source
+	deleted := 0
+	for _, filePath := range extraneousPaths {
+		if showProgress {
+			fmt.Fprintf(os.Stderr, "  Deleting %s (not in source)\n", filePath)
 		}
+		if err := targetClient.DeleteFile(ctx, targetRepo, filePath); err != nil {
+			if !continueOnError {
+				return fmt.Errorf("failed to delete extraneous file '%s': %w", filePath, err)
+			}
+			targetClient.Errorf("Failed to delete extraneous file '%s': %v", filePath, err)
+			failures.Add(filePath, fmt.Errorf("delete extraneous file: %w", err))
+			continue
+		}
+		deleted++
+	}
 
-		transferred++
+	fmt.Printf("\nTransfer completed: %d files transferred, %d files skipped, %d files deleted\n", transferred, skipped, deleted)
+
+	if failEmpty && total+extraneous == 0 {
+		return fmt.Errorf("%w: source and target repositories are empty", nexus.ErrNoFiles)
 	}
 
-	fmt.Printf("\nTransfer completed: %d files transferred, %d files skipped\n", transferred, skipped)
+	return failures.Err("sync", total+extraneous)
+}
 
+// validateTimeouts checks the timeout of both sides, as each profile may set its own
+func validateTimeouts(sourceConfig, targetConfig *config.Config) error {
+	if err := sourceConfig.ValidateTimeout(); err != nil {
+		return fmt.Errorf("source configuration error: %w", err)
+	}
+	if err := targetConfig.ValidateTimeout(); err != nil {
+		return fmt.Errorf("target configuration error: %w", err)
+	}
 	return nil
 }
+
+// withDeadline limits ctx to the --deadline budget; 0 means no limit
+func withDeadline(ctx context.Context, deadline time.Duration) (context.Context, context.CancelFunc) {
+	if deadline <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, deadline)
+}
+
+// deadlineReached reports whether runCtx, created by withDeadline from parent,
+// has run out of time while parent itself is still alive
+func deadlineReached(parent, runCtx context.Context) bool {
+	return parent.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded)
+}
+
+// transferWithTimeout runs transfer under a per-file deadline when timeout is set.
+// timedOut reports that the transfer failed because that deadline expired rather
+// than because ctx was cancelled.
+func transferWithTimeout(ctx context.Context, timeout time.Duration, transfer func(context.Context) error) (timedOut bool, err error) {
+	if timeout <= 0 {
+		return false, transfer(ctx)
+	}
+	fileCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err = transfer(fileCtx)
+	return err != nil && ctx.Err() == nil && errors.Is(fileCtx.Err(), context.DeadlineExceeded), err
+}
+
+// assetBytes returns the size the search API reported for a file, or -1 when
+// it reported none
+func assetBytes(file nexus.Asset) int64 {
+	if file.FileSize > 0 {
+		return file.FileSize
+	}
+	return -1
+}
+
+// unchanged reports whether the source and target files hold the same content.
+// Checksums from the search API are compared when both sides share an algorithm;
+// otherwise the file sizes are compared.
+func unchanged(ctx context.Context, sourceClient, targetClient *nexus.NexusClient, sourceRepo, targetRepo string, source, target nexus.Asset) (bool, error) {
+	if algorithm, sourceHash, targetHash := nexus.CommonChecksum(source.Checksum, target.Checksum); algorithm != "" {
+		return sourceHash == targetHash, nil
+	}
+
+	sourceSize, err := sourceClient.GetFileSize(ctx, sourceRepo, source.Path)
+	if err != nil {
+		return false, err
+	}
+	targetSize, err := targetClient.GetFileSize(ctx, targetRepo, target.Path)
+	if err != nil {
+		return false, err
+	}
+	return sourceSize == targetSize, nil
+}
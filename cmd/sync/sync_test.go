@@ -0,0 +1,374 @@
+package sync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"nexus-util/config"
+	"nexus-util/nexus"
+)
+
+func TestPlanBidirectional(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	// HEAD requests report modification times for assets the search did not date
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		modified := older
+		if strings.HasPrefix(r.URL.Path, "/repository/target/") {
+			modified = newer
+		}
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	source := endpoint{name: "source", client: client, repo: "source"}
+	target := endpoint{name: "target", client: client, repo: "target"}
+
+	sourceFiles := []nexus.Asset{
+		{Path: "only-source.txt"},
+		{Path: "same.txt", Checksum: map[string]string{"sha1": "aaa"}},
+		{Path: "source-newer.txt", Checksum: map[string]string{"sha1": "bbb"}, LastModified: &newer},
+		{Path: "target-newer.txt", Checksum: map[string]string{"sha1": "ccc"}},
+	}
+	targetFiles := []nexus.Asset{
+		{Path: "only-target.txt"},
+		{Path: "same.txt", Checksum: map[string]string{"sha1": "AAA"}},
+		{Path: "source-newer.txt", Checksum: map[string]string{"sha1": "ddd"}, LastModified: &older},
+		{Path: "target-newer.txt", Checksum: map[string]string{"sha1": "eee"}},
+	}
+
+	tests := []struct {
+		conflict      string
+		wantTransfers []string
+		wantConflicts []string
+	}{
+		{
+			conflict:      "",
+			wantTransfers: []string{"only-source.txt source->target", "only-target.txt target->source"},
+			wantConflicts: []string{"source-newer.txt", "target-newer.txt"},
+		},
+		{
+			conflict: conflictNewest,
+			wantTransfers: []string{
+				"only-source.txt source->target",
+				"source-newer.txt source->target",
+				"target-newer.txt target->source",
+				"only-target.txt target->source",
+			},
+		},
+		{
+			conflict: conflictSource,
+			wantTransfers: []string{
+				"only-source.txt source->target",
+				"source-newer.txt source->target",
+				"target-newer.txt source->target",
+				"only-target.txt target->source",
+			},
+		},
+		{
+			conflict: conflictTarget,
+			wantTransfers: []string{
+				"only-source.txt source->target",
+				"source-newer.txt target->source",
+				"target-newer.txt target->source",
+				"only-target.txt target->source",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("conflict="+tt.conflict, func(t *testing.T) {
+			plan, err := planBidirectional(context.Background(), source, target, sourceFiles, targetFiles, tt.conflict)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var transfers []string
+			for _, job := range plan.transfers {
+				transfers = append(transfers, job.file.Path+" "+job.from.name+"->"+job.to.name)
+			}
+			if !reflect.DeepEqual(transfers, tt.wantTransfers) {
+				t.Errorf("transfers = %v, want %v", transfers, tt.wantTransfers)
+			}
+			if !reflect.DeepEqual(plan.conflicts, tt.wantConflicts) {
+				t.Errorf("conflicts = %v, want %v", plan.conflicts, tt.wantConflicts)
+			}
+			if plan.unchanged != 1 {
+				t.Errorf("unchanged = %d, want 1", plan.unchanged)
+			}
+		})
+	}
+}
+
+func TestValidateConflict(t *testing.T) {
+	for _, value := range []string{"", "newest", "source", "target"} {
+		if err := validateConflict(value); err != nil {
+			t.Errorf("validateConflict(%q) = %v", value, err)
+		}
+	}
+	if err := validateConflict("oldest"); err == nil {
+		t.Error("expected error for unknown conflict strategy")
+	}
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	valid := &config.Config{Timeout: "30s"}
+	invalid := &config.Config{Timeout: "soon"}
+
+	if err := validateTimeouts(valid, &config.Config{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := validateTimeouts(invalid, valid); err == nil || !strings.Contains(err.Error(), "source") {
+		t.Errorf("Expected source timeout error, got: %v", err)
+	}
+	if err := validateTimeouts(valid, invalid); err == nil || !strings.Contains(err.Error(), "target") {
+		t.Errorf("Expected target timeout error, got: %v", err)
+	}
+}
+
+func TestTransferWithTimeout(t *testing.T) {
+	wait := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	timedOut, err := transferWithTimeout(context.Background(), 10*time.Millisecond, wait)
+	if err == nil || !timedOut {
+		t.Errorf("expected a per-file timeout, got timedOut=%v err=%v", timedOut, err)
+	}
+
+	// Cancelling the whole run is not a per-file timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	timedOut, err = transferWithTimeout(ctx, time.Minute, wait)
+	if err == nil || timedOut {
+		t.Errorf("expected cancellation without timeout, got timedOut=%v err=%v", timedOut, err)
+	}
+
+	timedOut, err = transferWithTimeout(context.Background(), 0, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("expected no deadline without a timeout")
+		}
+		return nil
+	})
+	if err != nil || timedOut {
+		t.Errorf("expected success, got timedOut=%v err=%v", timedOut, err)
+	}
+}
+
+func TestDeadlineReached(t *testing.T) {
+	parent := context.Background()
+	ctx, cancel := withDeadline(parent, time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if !deadlineReached(parent, ctx) {
+		t.Error("expected the deadline to be reported")
+	}
+
+	cancelled, stop := context.WithCancel(context.Background())
+	ctx, cancel = withDeadline(cancelled, time.Millisecond)
+	defer cancel()
+	stop()
+	<-ctx.Done()
+	if deadlineReached(cancelled, ctx) {
+		t.Error("expected an interrupted run not to count as a deadline")
+	}
+
+	ctx, cancel = withDeadline(parent, 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok || deadlineReached(parent, ctx) {
+		t.Error("expected no deadline for 0")
+	}
+}
+
+func TestLoadDiffPlan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		t.Helper()
+		fileName := filepath.Join(dir, "diff.json")
+		if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return fileName
+	}
+
+	comparison := `"comparison": {"source": {"type": "nexus", "repository": "src", "path": "releases"}, "target": {"type": "nexus", "repository": "dst", "path": "releases"}}`
+	fileName := write(`{"identical": [], "only_source": ["a.txt"], "only_target": ["old/b.txt"],
+		"different": [{"path": "c.txt", "algorithm": "sha1"}], ` + comparison + `}`)
+
+	plan, err := loadDiffPlan(fileName, "src", "dst")
+	if err != nil {
+		t.Fatalf("loadDiffPlan failed: %v", err)
+	}
+	client := nexus.NewNexusClientWithRetry("http://nexus.example.com", "", "", true, false, false, nexus.NoRetryConfig())
+	var transfers []string
+	for _, asset := range plan.transfers(client, "src") {
+		transfers = append(transfers, asset.DownloadUrl)
+	}
+	want := []string{"http://nexus.example.com/repository/src/releases/a.txt", "http://nexus.example.com/repository/src/releases/c.txt"}
+	if !reflect.DeepEqual(transfers, want) {
+		t.Errorf("expected transfers %v, got %v", want, transfers)
+	}
+	if got := plan.extraneous(); !reflect.DeepEqual(got, []string{"releases/old/b.txt"}) {
+		t.Errorf("unexpected extraneous files: %v", got)
+	}
+
+	for name, tc := range map[string]struct {
+		content string
+		errText string
+	}{
+		"not a diff":     {`{"items": []}`, "is not a diff result"},
+		"wrong repo":     {`{"only_source": [], "only_target": [], "different": [], ` + comparison + `}`, "not 'other'"},
+		"local target":   {`{"only_source": [], "only_target": [], "different": [], "comparison": {"source": {"type": "nexus", "repository": "src"}, "target": {"type": "local", "path": "./dl"}}}`, "only diffs between two repositories"},
+		"escaping path":  {`{"only_source": ["../etc/passwd"], "only_target": [], "different": []}`, "invalid path"},
+		"malformed json": {`{"only_source": `, "error parsing diff"},
+	} {
+		targetRepo := "dst"
+		if name == "wrong repo" {
+			targetRepo = "other"
+		}
+		if _, err := loadDiffPlan(write(tc.content), "src", targetRepo); err == nil || !strings.Contains(err.Error(), tc.errText) {
+			t.Errorf("%s: expected error containing %q, got %v", name, tc.errText, err)
+		}
+	}
+}
+
+func TestSyncState(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "state.json")
+	source := repositoryKey("http://source.example.com/", "repo")
+	target := repositoryKey("http://target.example.com", "repo")
+
+	state, err := loadSyncState(fileName)
+	if err != nil {
+		t.Fatalf("loadSyncState failed for a missing file: %v", err)
+	}
+	files := []nexus.Asset{
+		{Path: "a.txt", Checksum: map[string]string{"sha1": "aaa", "SHA256": "AAA256"}},
+		{Path: "b.txt", Checksum: map[string]string{"md5": "bbb"}},
+		{Path: "unknown.txt"},
+	}
+	if changed, _ := state.pending(source, target, files); len(changed) != len(files) {
+		t.Errorf("expected every file to be pending without a state, got %d", len(changed))
+	}
+
+	state.record(source, target, files, time.Now())
+	if err := state.writeFile(fileName); err != nil {
+		t.Fatalf("writeFile failed: %v", err)
+	}
+	if got := state.Sources[source].Files["a.txt"]; got != "sha256:aaa256" {
+		t.Errorf("expected the strongest checksum to be recorded, got %q", got)
+	}
+
+	state, err = loadSyncState(fileName)
+	if err != nil {
+		t.Fatalf("loadSyncState failed: %v", err)
+	}
+	next := []nexus.Asset{
+		{Path: "a.txt", Checksum: map[string]string{"sha256": "aaa256"}},
+		{Path: "b.txt", Checksum: map[string]string{"md5": "changed"}},
+		{Path: "unknown.txt"},
+		{Path: "new.txt", Checksum: map[string]string{"sha1": "nnn"}},
+	}
+	changed, unchanged := state.pending(source, target, next)
+	var changedPaths []string
+	for _, file := range changed {
+		changedPaths = append(changedPaths, file.Path)
+	}
+	if want := []string{"b.txt", "unknown.txt", "new.txt"}; !reflect.DeepEqual(changedPaths, want) {
+		t.Errorf("expected changed files %v, got %v", want, changedPaths)
+	}
+	if len(unchanged) != 1 || unchanged[0].Path != "a.txt" {
+		t.Errorf("expected a.txt to be unchanged, got %+v", unchanged)
+	}
+
+	// A state recorded for another target does not apply
+	if changed, _ := state.pending(source, repositoryKey("http://other.example.com", "repo"), next); len(changed) != len(next) {
+		t.Errorf("expected every file to be pending for another target, got %d", len(changed))
+	}
+
+	if err := os.WriteFile(fileName, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	if _, err := loadSyncState(fileName); err == nil {
+		t.Error("expected an error for an unsupported state version")
+	}
+}
+
+func TestPreviewTransfers(t *testing.T) {
+	// The target holds exists.txt only
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repository/target/exists.txt" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewNexusClientWithRetry(server.URL, "", "", true, false, false, nexus.NoRetryConfig())
+	skip := &skipCheck{sourceClient: client, targetClient: client, sourceRepo: "source", targetRepo: "target", existing: true}
+	files := []nexus.Asset{{Path: "exists.txt"}, {Path: "new.txt"}, {Path: "other.txt"}}
+	unchangedSinceState := []nexus.Asset{{Path: "state.txt"}}
+
+	plan := previewTransfers(context.Background(), skip, files, unchangedSinceState, 2)
+	if want := []string{"new.txt", "other.txt"}; !reflect.DeepEqual(plan.Transfer, want) {
+		t.Errorf("Expected transfers %v, got %v", want, plan.Transfer)
+	}
+	wantSkip := []skippedFile{{Path: "state.txt", Reason: reasonUnchangedSinceState}, {Path: "exists.txt", Reason: reasonExists}}
+	if !reflect.DeepEqual(plan.Skip, wantSkip) {
+		t.Errorf("Expected skips %v, got %v", wantSkip, plan.Skip)
+	}
+
+	var text strings.Builder
+	if err := plan.write(&text, outputText); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if !strings.HasPrefix(text.String(), "Dry run: 2 files to transfer, 2 files to skip\n") || !strings.Contains(text.String(), "  exists.txt (already exists)\n") {
+		t.Errorf("Unexpected text plan:\n%s", text.String())
+	}
+
+	// Without --delete-extraneous the JSON plan has no delete list
+	var out strings.Builder
+	if err := plan.write(&out, outputJSON); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if strings.Contains(out.String(), `"delete"`) {
+		t.Errorf("Unexpected delete list in %s", out.String())
+	}
+	plan.Delete = &[]string{}
+	out.Reset()
+	if err := plan.write(&out, outputJSON); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"delete": []`) {
+		t.Errorf("Expected an empty delete list in %s", out.String())
+	}
+}
+
+func TestConfirmDeletes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if got := confirmDeletes("target", []string{"old.txt"}, strings.NewReader(tt.input), &out); got != tt.want {
+			t.Errorf("confirmDeletes(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if !strings.Contains(out.String(), "About to delete 1 extraneous files from target repository 'target':\n  old.txt\n") {
+			t.Errorf("Unexpected prompt:\n%s", out.String())
+		}
+	}
+}
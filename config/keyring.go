@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// KeyringService is the service name secrets are stored under in the OS keyring
+	KeyringService = "nexus-util"
+	// keyringPrefix marks a config value as a reference to a keyring entry
+	keyringPrefix = "keyring:"
+)
+
+// KeyringRef returns the config value that refers to the keyring entry for user
+func KeyringRef(user string) string {
+	return keyringPrefix + KeyringService + "/" + user
+}
+
+// StorePassword saves the password for user in the OS keyring and returns the
+// reference to write to the config file instead of the plaintext password
+func StorePassword(user, password string) (string, error) {
+	if err := keyring.Set(KeyringService, user, password); err != nil {
+		return "", fmt.Errorf("error storing password in keyring: %w", err)
+	}
+	return KeyringRef(user), nil
+}
+
+// resolveSecret returns value unchanged unless it is a keyring reference
+// (keyring:<service>/<user>), in which case the secret is read from the keyring
+func resolveSecret(value string) (string, error) {
+	ref, ok := strings.CutPrefix(value, keyringPrefix)
+	if !ok {
+		return value, nil
+	}
+
+	service, user, ok := strings.Cut(ref, "/")
+	if !ok || service == "" || user == "" {
+		return "", fmt.Errorf("invalid keyring reference '%s': expected keyring:<service>/<user>", value)
+	}
+
+	secret, err := keyring.Get(service, user)
+	if err != nil {
+		return "", fmt.Errorf("error reading '%s' from keyring: %w", value, err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,135 @@
+package nexus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AgeFilter selects assets by their last modification time. A zero cutoff
+// does not restrict the assets.
+type AgeFilter struct {
+	// OlderThan keeps assets modified before this time
+	OlderThan time.Time
+	// NewerThan keeps assets modified after this time
+	NewerThan time.Time
+}
+
+// ParseAgeFilter builds a filter from --older-than and --newer-than values,
+// each either an age relative to now or a date (see ParseTimeSpec). Empty
+// values leave the corresponding side unrestricted.
+func ParseAgeFilter(olderThan, newerThan string, now time.Time) (AgeFilter, error) {
+	var filter AgeFilter
+	var err error
+	if olderThan != "" {
+		if filter.OlderThan, err = ParseTimeSpec(olderThan, now); err != nil {
+			return AgeFilter{}, fmt.Errorf("invalid --older-than: %w", err)
+		}
+	}
+	if newerThan != "" {
+		if filter.NewerThan, err = ParseTimeSpec(newerThan, now); err != nil {
+			return AgeFilter{}, fmt.Errorf("invalid --newer-than: %w", err)
+		}
+	}
+	if !filter.OlderThan.IsZero() && !filter.NewerThan.IsZero() && !filter.NewerThan.Before(filter.OlderThan) {
+		return AgeFilter{}, fmt.Errorf("--newer-than %s and --older-than %s select no time range", newerThan, olderThan)
+	}
+	return filter, nil
+}
+
+// ParseTimeSpec turns an age such as "90d", "2w" or "36h" into the time that
+// long before now, and parses anything else as a date: "2024-01-31" (midnight
+// in local time) or an RFC 3339 timestamp such as "2024-01-31T12:00:00Z".
+func ParseTimeSpec(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if age, ok := parseAge(value); ok {
+		return now.Add(-age), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("'%s' is neither an age such as 90d, 2w or 36h nor a date such as 2024-01-31", value)
+}
+
+// parseAge parses a duration with d (days) and w (weeks) in addition to the
+// units of time.ParseDuration. It reports false for anything else, including
+// negative values and dates, which contain a '-'.
+func parseAge(value string) (time.Duration, bool) {
+	if value == "" || strings.ContainsAny(value, "-:") {
+		return 0, false
+	}
+	unit := value[len(value)-1]
+	if unit != 'd' && unit != 'w' {
+		age, err := time.ParseDuration(value)
+		return age, err == nil
+	}
+	n, err := strconv.ParseFloat(value[:len(value)-1], 64)
+	if err != nil {
+		return 0, false
+	}
+	day := 24 * time.Hour
+	if unit == 'w' {
+		day *= 7
+	}
+	return time.Duration(n * float64(day)), true
+}
+
+// Active reports whether the filter restricts anything
+func (f AgeFilter) Active() bool {
+	return !f.OlderThan.IsZero() || !f.NewerThan.IsZero()
+}
+
+// Matches reports whether a modification time lies within the filter's range
+func (f AgeFilter) Matches(modified time.Time) bool {
+	if !f.OlderThan.IsZero() && !modified.Before(f.OlderThan) {
+		return false
+	}
+	if !f.NewerThan.IsZero() && !modified.After(f.NewerThan) {
+		return false
+	}
+	return true
+}
+
+// ModificationTime returns the last modification time of file, from the search
+// response when it has one and from a HEAD request otherwise
+func (c *NexusClient) ModificationTime(ctx context.Context, repository string, file Asset) (time.Time, error) {
+	if file.LastModified != nil {
+		return *file.LastModified, nil
+	}
+	info, err := c.GetAssetInfo(ctx, repository, file.Path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get its modification time: %w", err)
+	}
+	if info.LastModified == nil {
+		return time.Time{}, fmt.Errorf("the server did not report its modification time")
+	}
+	return *info.LastModified, nil
+}
+
+// FilterByAge returns the files whose last modification time matches filter.
+// Files the search response gave no time for are looked up with a HEAD
+// request; files whose time is still unknown are left out with a warning, so
+// that a cleanup never deletes a file it cannot date.
+func (c *NexusClient) FilterByAge(ctx context.Context, repository string, files []Asset, filter AgeFilter) []Asset {
+	if !filter.Active() {
+		return files
+	}
+	var matched []Asset
+	for _, file := range files {
+		modified, err := c.ModificationTime(ctx, repository, file)
+		if err != nil {
+			c.Warnf("Skipping '%s': %v", file.Path, err)
+			continue
+		}
+		if filter.Matches(modified) {
+			file.LastModified = &modified
+			matched = append(matched, file)
+		}
+	}
+	return matched
+}
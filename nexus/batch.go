@@ -0,0 +1,39 @@
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// BatchErrors collects per-file failures of a batch operation that keeps going
+// after individual files fail. It is safe for concurrent use.
+type BatchErrors struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add records that the file at path failed with err
+func (b *BatchErrors) Add(path string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.errs = append(b.errs, fmt.Errorf("%s: %w", path, err))
+}
+
+// Len returns the number of recorded failures
+func (b *BatchErrors) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.errs)
+}
+
+// Err returns nil if nothing failed, otherwise one error listing every failed
+// file with its reason, e.g. "failed to upload 2 of 10 files:\na.txt: ...\nb.txt: ..."
+func (b *BatchErrors) Err(action string, total int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to %s %d of %d files:\n%w", action, len(b.errs), total, errors.Join(b.errs...))
+}
@@ -0,0 +1,127 @@
+package nexus
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DownloadCache keeps downloaded files in a local directory keyed by their
+// checksum, so that an asset whose checksum is already cached is copied from
+// disk instead of being downloaded again. Entries are stored as
+// <dir>/<algorithm>/<first two digits>/<checksum>.
+type DownloadCache struct {
+	Dir string
+}
+
+// NewDownloadCache returns a cache in dir, creating the directory if needed
+func NewDownloadCache(dir string) (*DownloadCache, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DownloadCache{Dir: dir}, nil
+}
+
+// entryPath returns where the file with the given checksum is cached. The
+// checksum comes from the server, so anything but a hex digest is refused
+// rather than being turned into a path.
+func (d *DownloadCache) entryPath(algorithm, checksum string) (string, bool) {
+	if len(checksum) < 2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return "", false
+	}
+	return filepath.Join(d.Dir, algorithm, checksum[:2], checksum), true
+}
+
+// fetch places the cached file with the given checksum at destPath, as a hard
+// link when possible and as a copy otherwise. It reports false when the file
+// is not cached; a cached file that no longer matches its checksum is removed
+// and treated as missing.
+func (d *DownloadCache) fetch(algorithm, checksum, destPath string) (bool, error) {
+	entry, ok := d.entryPath(algorithm, checksum)
+	if !ok {
+		return false, nil
+	}
+	if _, err := os.Stat(entry); err != nil {
+		return false, nil
+	}
+	if actual, err := hashFile(entry, algorithm); err != nil || actual != checksum {
+		os.Remove(entry)
+		return false, nil
+	}
+
+	if err := createDir(filepath.Dir(destPath)); err != nil {
+		return false, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	// Replace an existing file instead of failing to link over it
+	if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to replace destination file: %w", err)
+	}
+	if err := os.Link(entry, destPath); err == nil {
+		return true, nil
+	}
+	if err := copyFile(entry, destPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// store copies a downloaded file into the cache under its checksum. The copy
+// is written to a temporary file and renamed, so parallel downloads of the same
+// content never expose a partial entry.
+func (d *DownloadCache) store(algorithm, checksum, srcPath string) error {
+	entry, ok := d.entryPath(algorithm, checksum)
+	if !ok {
+		return fmt.Errorf("invalid %s checksum '%s'", algorithm, checksum)
+	}
+	if _, err := os.Stat(entry); err == nil {
+		return nil
+	}
+	if err := createDir(filepath.Dir(entry)); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(entry), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	if err := copyFile(srcPath, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, entry); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
+
+// copyFile copies the content of src to dst, replacing dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	return nil
+}
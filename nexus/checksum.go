@@ -0,0 +1,31 @@
+package nexus
+
+import "strings"
+
+// ChecksumPreference lists checksum algorithms from strongest to weakest
+var ChecksumPreference = []string{"sha256", "sha1", "md5"}
+
+// NormalizeChecksums lower-cases algorithm names and values and drops empty entries
+func NormalizeChecksums(input map[string]string) map[string]string {
+	output := make(map[string]string, len(input))
+	for key, value := range input {
+		if value == "" {
+			continue
+		}
+		output[strings.ToLower(key)] = strings.ToLower(value)
+	}
+	return output
+}
+
+// CommonChecksum returns the strongest algorithm present in both checksum sets
+// together with both values. The algorithm is empty when there is no overlap.
+func CommonChecksum(a, b map[string]string) (algorithm string, aValue string, bValue string) {
+	a = NormalizeChecksums(a)
+	b = NormalizeChecksums(b)
+	for _, algorithm := range ChecksumPreference {
+		if a[algorithm] != "" && b[algorithm] != "" {
+			return algorithm, a[algorithm], b[algorithm]
+		}
+	}
+	return "", "", ""
+}
@@ -0,0 +1,109 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Component represents a Nexus component: a versioned package made of one or
+// more assets, e.g. a Maven artifact with its jar, pom and checksums
+type Component struct {
+	ID         string  `json:"id"`
+	Repository string  `json:"repository"`
+	Format     string  `json:"format"`
+	Group      string  `json:"group"`
+	Name       string  `json:"name"`
+	Version    string  `json:"version"`
+	Assets     []Asset `json:"assets"`
+}
+
+// ListComponentsResponse represents one page of the components API response
+type ListComponentsResponse struct {
+	Items             []Component `json:"items"`
+	ContinuationToken string      `json:"continuationToken"`
+}
+
+// ListComponents lists all components of a repository, following continuation
+// tokens until every page has been fetched
+func (c *NexusClient) ListComponents(ctx context.Context, repository string) ([]Component, error) {
+	query := url.Values{}
+	query.Set("repository", repository)
+
+	var components []Component
+	tokens := pageTokens{}
+	for {
+		componentsURL := c.apiURL("v1/components?" + query.Encode())
+
+		c.Debugf("REST API request: %s", redactURL(componentsURL))
+
+		resp, err := c.makeRequest(ctx, "GET", componentsURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list components: %w", err)
+		}
+
+		if resp.StatusCode == httpStatusNotFound {
+			resp.Body.Close()
+			return nil, fmt.Errorf("repository '%s' does not exist", repository)
+		}
+		if resp.StatusCode != httpStatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("components request failed with status %d", resp.StatusCode)
+		}
+
+		var page ListComponentsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode components response: %w", err)
+		}
+		resp.Body.Close()
+
+		components = append(components, page.Items...)
+
+		if page.ContinuationToken == "" {
+			break
+		}
+		if err := tokens.next(page.ContinuationToken); err != nil {
+			return nil, err
+		}
+		query.Set("continuationToken", page.ContinuationToken)
+	}
+
+	c.Logf("Found %d components", len(components))
+	return components, nil
+}
+
+// DeleteComponent deletes a component and all of its assets by component ID
+func (c *NexusClient) DeleteComponent(ctx context.Context, componentID string) error {
+	componentURL := c.apiURL("v1/components/" + url.PathEscape(componentID))
+
+	c.Debugf("REST API request: %s", redactURL(componentURL))
+
+	if c.DryRun {
+		c.Logf("Dry run: Would delete component '%s'", componentID)
+		return nil
+	}
+
+	resp, err := c.makeRequest(ctx, "DELETE", componentURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete component: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= httpStatusOK && resp.StatusCode < 300:
+		c.Logf("Component '%s' deleted", componentID)
+		return nil
+	case resp.StatusCode == httpStatusNotFound:
+		return fmt.Errorf("component '%s' does not exist", componentID)
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("insufficient permissions to delete component '%s' (status %d)", componentID, resp.StatusCode)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete component (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
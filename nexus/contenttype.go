@@ -0,0 +1,56 @@
+package nexus
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// sniffLen is the number of leading bytes http.DetectContentType looks at
+const sniffLen = 512
+
+// defaultContentType is used when neither the extension nor the content identify a file
+const defaultContentType = "application/octet-stream"
+
+// detectContentType returns the MIME type for a file, preferring its extension
+// and falling back to sniffing the first bytes of its content
+func detectContentType(name string, head []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+	if len(head) == 0 {
+		return defaultContentType
+	}
+	if len(head) > sniffLen {
+		head = head[:sniffLen]
+	}
+	return http.DetectContentType(head)
+}
+
+// uploadContentType returns the Content-Type to send for an uploaded file:
+// the configured override if any, otherwise the type detected from name and head
+func (c *NexusClient) uploadContentType(name string, head []byte) string {
+	if c.ContentType != "" {
+		return c.ContentType
+	}
+	return detectContentType(name, head)
+}
+
+// sniffContentType determines the Content-Type of a seekable file. The first
+// bytes are only read when the extension is unknown, and the reader is rewound.
+func (c *NexusClient) sniffContentType(name string, r io.ReadSeeker) (string, error) {
+	if c.ContentType != "" || mime.TypeByExtension(filepath.Ext(name)) != "" {
+		return c.uploadContentType(name, nil), nil
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return detectContentType(name, head[:n]), nil
+}
@@ -0,0 +1,47 @@
+package nexus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// availableSpace reports the free bytes available to the current user on the
+// filesystem holding path. It is a variable so tests can replace it.
+var availableSpace = diskFree
+
+// checkDiskSpace returns an error if the filesystem holding dir has less than
+// needed bytes available. If free space cannot be determined a warning is logged.
+func (c *NexusClient) checkDiskSpace(dir string, needed int64) error {
+	if c.SkipSpaceCheck || needed <= 0 {
+		return nil
+	}
+
+	// The destination may not exist yet; check the closest existing parent
+	path, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve destination: %w", err)
+	}
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	free, err := availableSpace(path)
+	if err != nil {
+		c.Warnf("Could not determine free disk space for '%s': %v", dir, err)
+		return nil
+	}
+	if uint64(needed) > free {
+		return fmt.Errorf("not enough disk space in '%s': %d bytes needed, %d bytes available", dir, needed, free)
+	}
+
+	c.Debugf("Disk space check passed for '%s': %d bytes needed, %d bytes available", dir, needed, free)
+	return nil
+}
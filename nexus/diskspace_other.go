@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !openbsd && !windows
+
+package nexus
+
+import "errors"
+
+// diskFree is not supported on this platform
+func diskFree(path string) (uint64, error) {
+	return 0, errors.New("free disk space is not available on this platform")
+}
@@ -0,0 +1,112 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Progress output formats of the --progress-format flag
+const (
+	ProgressFormatText = "text"
+	ProgressFormatJSON = "json"
+)
+
+// ValidateProgressFormat checks the value of a --progress-format flag
+func ValidateProgressFormat(format string) error {
+	switch format {
+	case ProgressFormatText, ProgressFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid progress format '%s': must be %s or %s", format, ProgressFormatText, ProgressFormatJSON)
+	}
+}
+
+// Names of progress events
+const (
+	EventStarted   = "started"
+	EventCompleted = "completed"
+	EventSkipped   = "skipped"
+	EventError     = "error"
+)
+
+// ProgressEvent is one line of JSON progress output
+type ProgressEvent struct {
+	// Index numbers the events of an emitter from 1 in the order they were written
+	Index int64 `json:"index"`
+	// Total is the number of files of the operation, 0 when it is not known
+	Total int       `json:"total"`
+	Event string    `json:"event"`
+	Path  string    `json:"path"`
+	Time  time.Time `json:"time"`
+	// Bytes is the size of a completed file, when known
+	Bytes *int64 `json:"bytes,omitempty"`
+	// Reason tells why a file was skipped
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// EventEmitter writes one JSON object per line for every file an operation
+// starts, completes, skips or fails on. It is safe for concurrent use, and all
+// methods do nothing on a nil emitter so callers need not check for one.
+type EventEmitter struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	index int64
+	total int
+}
+
+// NewEventEmitter returns an emitter writing to w
+func NewEventEmitter(w io.Writer) *EventEmitter {
+	return &EventEmitter{enc: json.NewEncoder(w)}
+}
+
+// SetTotal sets the number of files reported with the following events
+func (e *EventEmitter) SetTotal(total int) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	e.total = total
+	e.mu.Unlock()
+}
+
+// Started reports that the transfer of path begins
+func (e *EventEmitter) Started(path string) {
+	e.emit(ProgressEvent{Event: EventStarted, Path: path})
+}
+
+// Completed reports that path was transferred; bytes is negative when the size is not known
+func (e *EventEmitter) Completed(path string, bytes int64) {
+	event := ProgressEvent{Event: EventCompleted, Path: path}
+	if bytes >= 0 {
+		event.Bytes = &bytes
+	}
+	e.emit(event)
+}
+
+// Skipped reports that path was not transferred, and why
+func (e *EventEmitter) Skipped(path string, reason string) {
+	e.emit(ProgressEvent{Event: EventSkipped, Path: path, Reason: reason})
+}
+
+// Failed reports that the transfer of path failed
+func (e *EventEmitter) Failed(path string, err error) {
+	e.emit(ProgressEvent{Event: EventError, Path: path, Error: err.Error()})
+}
+
+func (e *EventEmitter) emit(event ProgressEvent) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.index++
+	event.Index = e.index
+	event.Total = e.total
+	event.Time = time.Now().UTC()
+	// Progress output is best effort, like the text progress lines
+	_ = e.enc.Encode(event)
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package nexus
+
+import "os"
+
+// fileIdentity returns the resolved absolute path of a file; inodes are not
+// available on this platform
+func fileIdentity(path string, _ os.FileInfo) fileID {
+	return resolvedFileID(path)
+}
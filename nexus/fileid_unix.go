@@ -0,0 +1,16 @@
+//go:build unix
+
+package nexus
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileIdentity returns the device and inode of a file
+func fileIdentity(path string, info os.FileInfo) fileID {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return fileID{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}
+	}
+	return resolvedFileID(path)
+}
@@ -0,0 +1,74 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// managedHeaders are set by the HTTP transport from the request itself and
+// cannot be replaced by extra upload headers
+var managedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Host":              true,
+	"Transfer-Encoding": true,
+}
+
+// ParseHeaders parses "Name: value" strings, as given to push --header, into
+// a header map. A later header with the same name replaces an earlier one.
+func ParseHeaders(specs []string) (map[string]string, error) {
+	headers := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header '%s': expected 'Name: value'", spec)
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	if err := validateHeaders(headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// validateHeaders checks that every name is an HTTP token, that no value
+// contains line breaks and that no header is managed by the transport
+func validateHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if name == "" || strings.IndexFunc(name, func(r rune) bool { return !isTokenRune(r) }) >= 0 {
+			return fmt.Errorf("invalid header name '%s'", name)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return fmt.Errorf("invalid value for header '%s': must not contain line breaks", name)
+		}
+		if managedHeaders[http.CanonicalHeaderKey(name)] {
+			return fmt.Errorf("header '%s' is set automatically and cannot be overridden", name)
+		}
+	}
+	return nil
+}
+
+// isTokenRune reports whether r may appear in a header name (RFC 7230 token)
+func isTokenRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	default:
+		return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+	}
+}
+
+// uploadHeader returns the headers of an upload request: the Content-Type,
+// followed by the extra headers, which may replace it
+func uploadHeader(contentType string, extra []map[string]string) (http.Header, error) {
+	header := http.Header{"Content-Type": {contentType}}
+	for _, headers := range extra {
+		if err := validateHeaders(headers); err != nil {
+			return nil, err
+		}
+		for name, value := range headers {
+			header.Set(name, value)
+		}
+	}
+	return header, nil
+}
@@ -0,0 +1,127 @@
+package nexus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// Logger receives leveled log messages from NexusClient
+type Logger interface {
+	Debug(format string, args ...interface{})
+	Info(format string, args ...interface{})
+	Warn(format string, args ...interface{})
+	Error(format string, args ...interface{})
+}
+
+// stderrWriter writes to whatever os.Stderr currently is, so the default
+// logger follows later redirections of the standard error stream
+type stderrWriter struct{}
+
+func (stderrWriter) Write(p []byte) (int, error) {
+	return os.Stderr.Write(p)
+}
+
+// defaultLogger is used by clients without their own Logger. Logs go to stderr
+// so that stdout only carries command results.
+var defaultLogger Logger = NewTextLogger(stderrWriter{}, slog.LevelInfo)
+
+// SetDefaultLogger replaces the logger used by clients without their own Logger
+func SetDefaultLogger(logger Logger) {
+	defaultLogger = logger
+}
+
+// ParseLogLevel parses debug, info, warn or error
+func ParseLogLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level '%s': must be debug, info, warn or error", level)
+	}
+	return parsed, nil
+}
+
+// NewLogger returns a logger writing to w in the given format (text or json)
+func NewLogger(w io.Writer, format string, level slog.Level) (Logger, error) {
+	switch format {
+	case "", "text":
+		return NewTextLogger(w, level), nil
+	case "json":
+		return NewJSONLogger(w, level), nil
+	default:
+		return nil, fmt.Errorf("invalid log format '%s': must be text or json", format)
+	}
+}
+
+// textLogger prints plain messages, prefixing everything but info with its level
+type textLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level slog.Level
+}
+
+// NewTextLogger returns a logger printing human-readable lines at or above level
+func NewTextLogger(w io.Writer, level slog.Level) Logger {
+	return &textLogger{w: w, level: level}
+}
+
+func (l *textLogger) log(level slog.Level, prefix string, format string, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, "%s%s\n", prefix, fmt.Sprintf(format, args...))
+}
+
+func (l *textLogger) Debug(format string, args ...interface{}) {
+	l.log(slog.LevelDebug, "Debug: ", format, args...)
+}
+
+func (l *textLogger) Info(format string, args ...interface{}) {
+	l.log(slog.LevelInfo, "", format, args...)
+}
+
+func (l *textLogger) Warn(format string, args ...interface{}) {
+	l.log(slog.LevelWarn, "Warning: ", format, args...)
+}
+
+func (l *textLogger) Error(format string, args ...interface{}) {
+	l.log(slog.LevelError, "Error: ", format, args...)
+}
+
+// jsonLogger writes one JSON object per message
+type jsonLogger struct {
+	logger *slog.Logger
+}
+
+// NewJSONLogger returns a logger writing JSON lines at or above level
+func NewJSONLogger(w io.Writer, level slog.Level) Logger {
+	return &jsonLogger{logger: slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))}
+}
+
+func (l *jsonLogger) log(level slog.Level, format string, args ...interface{}) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+func (l *jsonLogger) Debug(format string, args ...interface{}) {
+	l.log(slog.LevelDebug, format, args...)
+}
+
+func (l *jsonLogger) Info(format string, args ...interface{}) {
+	l.log(slog.LevelInfo, format, args...)
+}
+
+func (l *jsonLogger) Warn(format string, args ...interface{}) {
+	l.log(slog.LevelWarn, format, args...)
+}
+
+func (l *jsonLogger) Error(format string, args ...interface{}) {
+	l.log(slog.LevelError, format, args...)
+}
@@ -0,0 +1,137 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Manifest formats supported by Manifest.WriteFile
+const (
+	ManifestFormatJSON      = "json"
+	ManifestFormatSHA256Sum = "sha256sum"
+)
+
+// ManifestEntry describes one downloaded asset
+type ManifestEntry struct {
+	// Path is the asset path in the repository
+	Path string `json:"path"`
+	// File is the local path the asset was written to, relative to the
+	// download destination and with forward slashes
+	File string `json:"file"`
+	// SHA256 is the hex digest of the downloaded content
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest collects the checksums of downloaded files. Set it as
+// NexusClient.Manifest to have every completed download recorded; the digest
+// is computed while the content is written, so no extra read is needed.
+// It is safe for concurrent use.
+type Manifest struct {
+	Repository string          `json:"repository"`
+	Source     string          `json:"source"`
+	Generated  time.Time       `json:"generated"`
+	Files      []ManifestEntry `json:"files"`
+
+	destination string
+	mu          sync.Mutex
+}
+
+// NewManifest creates an empty manifest for downloads from repository on the
+// Nexus server at source into the local destination directory
+func NewManifest(repository, source, destination string) *Manifest {
+	return &Manifest{
+		Repository:  repository,
+		Source:      redactURL(source),
+		Files:       []ManifestEntry{},
+		destination: destination,
+	}
+}
+
+// Add records a downloaded file. file is made relative to the destination so
+// that the manifest stays valid when the downloaded tree is moved.
+func (m *Manifest) Add(path, file, sha256 string) {
+	if m.destination != "" {
+		if rel, err := filepath.Rel(m.destination, file); err == nil {
+			file = rel
+		}
+	}
+	file = filepath.ToSlash(file)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Files = append(m.Files, ManifestEntry{Path: path, File: file, SHA256: sha256})
+}
+
+// Len returns the number of recorded files
+func (m *Manifest) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.Files)
+}
+
+// Write writes the manifest to w in the given format. Entries are sorted by
+// asset path so that repeated downloads produce the same manifest.
+// The sha256sum format can be checked with "sha256sum -c" from the download
+// destination; the repository and source are written as leading comment lines.
+func (m *Manifest) Write(w io.Writer, format string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+	if m.Generated.IsZero() {
+		m.Generated = time.Now().UTC()
+	}
+
+	switch format {
+	case ManifestFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(m)
+	case ManifestFormatSHA256Sum:
+		if _, err := fmt.Fprintf(w, "# repository: %s\n# source: %s\n# generated: %s\n",
+			m.Repository, m.Source, m.Generated.Format(time.RFC3339)); err != nil {
+			return err
+		}
+		for _, entry := range m.Files {
+			if _, err := fmt.Fprintf(w, "%s  %s\n", entry.SHA256, entry.File); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid manifest format '%s': must be %s or %s", format, ManifestFormatJSON, ManifestFormatSHA256Sum)
+	}
+}
+
+// WriteFile writes the manifest to path atomically: it is written to a
+// temporary file in the same directory and renamed into place, so readers
+// never see a partial manifest
+func (m *Manifest) WriteFile(path, format string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create manifest: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := m.Write(tmp, format); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	// CreateTemp creates the file readable by the owner only
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move manifest into place: %w", err)
+	}
+	return nil
+}
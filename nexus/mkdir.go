@@ -0,0 +1,29 @@
+package nexus
+
+import (
+	"os"
+	"sync"
+)
+
+// dirMu serialises the creation of download directories. Parallel downloads
+// into the same new directory would otherwise run os.MkdirAll for it at the
+// same time, which some filesystems answer with "file exists" for the loser.
+// It is shared by all clients and the download cache, as they may write below
+// the same destination.
+var dirMu sync.Mutex
+
+// createDir creates dir and any missing parents like os.MkdirAll, safely for
+// concurrent use. A directory that appears in the meantime, e.g. created by
+// another process, is not an error.
+func createDir(dir string) error {
+	dirMu.Lock()
+	defer dirMu.Unlock()
+
+	err := os.MkdirAll(dir, dirPerm)
+	if err != nil {
+		if info, statErr := os.Stat(dir); statErr == nil && info.IsDir() {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,187 @@
+package nexus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// UploadComponent uploads content as the file filename in directory of a raw
+// repository through the components API: a multipart/form-data POST with the
+// raw.directory, raw.asset1 and raw.asset1.filename fields. Some servers only
+// accept uploads through this endpoint instead of a PUT to the repository path.
+// Seekable content is streamed and rewound on retry; anything else is buffered.
+func (c *NexusClient) UploadComponent(ctx context.Context, repository string, directory string, filename string, content io.Reader, headers ...map[string]string) error {
+	destPath := JoinRemotePath(directory, filename)
+	if c.DryRun {
+		c.Logf("Dry run: Would upload '%s' to %s", destPath, redactURL(c.componentsURL(repository)))
+		return nil
+	}
+
+	c.Logf("Uploading '%s' through the components API...", destPath)
+	header, err := uploadHeader(c.uploadContentType(filename, nil), headers)
+	if err != nil {
+		return err
+	}
+	resp, err := c.postComponent(ctx, repository, destPath, content, header)
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < httpStatusOK || resp.StatusCode >= 300 {
+		return fmt.Errorf("upload failed with status %d", resp.StatusCode)
+	}
+	c.Logf("Upload completed")
+	return nil
+}
+
+// componentsURL returns the components API endpoint of a repository
+func (c *NexusClient) componentsURL(repository string) string {
+	return c.apiURL("v1/components?repository=" + url.QueryEscape(repository))
+}
+
+// postComponent sends content as the raw component file destPath. header holds
+// the upload headers; its Content-Type is replaced by the multipart one.
+func (c *NexusClient) postComponent(ctx context.Context, repository string, destPath string, content io.Reader, header http.Header) (*http.Response, error) {
+	destPath = JoinRemotePath("", destPath)
+	directory := path.Dir(destPath)
+	if directory == "." {
+		directory = "/"
+	}
+
+	body, contentType, err := multipartBody(directory, path.Base(destPath), content)
+	if err != nil {
+		return nil, err
+	}
+	header = header.Clone()
+	header.Set("Content-Type", contentType)
+	return c.makeRequestWithHeaders(ctx, "POST", c.componentsURL(repository), body, header)
+}
+
+// multipartBody returns the form of a raw component upload with content as its
+// only asset, and the Content-Type of the form. The form is seekable when
+// content is, and a stream body when content is one, so that makeRequest
+// streams it instead of buffering it.
+func multipartBody(directory string, filename string, content io.Reader) (io.Reader, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("raw.directory", directory); err != nil {
+		return nil, "", err
+	}
+	if err := w.WriteField("raw.asset1.filename", filename); err != nil {
+		return nil, "", err
+	}
+	if _, err := w.CreateFormFile("raw.asset1", filename); err != nil {
+		return nil, "", err
+	}
+	head := bytes.Clone(buf.Bytes())
+	buf.Reset()
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	tail := buf.Bytes()
+
+	if stream, ok := content.(*streamBody); ok {
+		length := stream.length
+		if length >= 0 {
+			length += int64(len(head) + len(tail))
+		}
+		body := io.MultiReader(bytes.NewReader(head), stream.Reader, bytes.NewReader(tail))
+		return &streamBody{Reader: body, length: length}, w.FormDataContentType(), nil
+	}
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		body, err := newConcatReadSeeker(bytes.NewReader(head), seeker, bytes.NewReader(tail))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to seek request body: %w", err)
+		}
+		return body, w.FormDataContentType(), nil
+	}
+	return io.MultiReader(bytes.NewReader(head), content, bytes.NewReader(tail)), w.FormDataContentType(), nil
+}
+
+// concatReadSeeker reads its parts one after another, each from the position
+// it had when the reader was created, and can seek across them
+type concatReadSeeker struct {
+	parts  []io.ReadSeeker
+	starts []int64
+	sizes  []int64
+	offset int64
+	// current is the part positioned at offset, or -1 after a seek
+	current int
+}
+
+func newConcatReadSeeker(parts ...io.ReadSeeker) (*concatReadSeeker, error) {
+	r := &concatReadSeeker{parts: parts, current: -1}
+	for _, part := range parts {
+		start, err := part.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		end, err := part.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		r.starts = append(r.starts, start)
+		r.sizes = append(r.sizes, end-start)
+	}
+	return r, nil
+}
+
+func (r *concatReadSeeker) size() int64 {
+	var total int64
+	for _, size := range r.sizes {
+		total += size
+	}
+	return total
+}
+
+func (r *concatReadSeeker) Read(p []byte) (int, error) {
+	base := int64(0)
+	for i, part := range r.parts {
+		if r.offset >= base+r.sizes[i] {
+			base += r.sizes[i]
+			continue
+		}
+		if r.current != i {
+			if _, err := part.Seek(r.starts[i]+r.offset-base, io.SeekStart); err != nil {
+				return 0, err
+			}
+			r.current = i
+		}
+		if remaining := base + r.sizes[i] - r.offset; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+		n, err := part.Read(p)
+		r.offset += int64(n)
+		if errors.Is(err, io.EOF) {
+			if r.offset < base+r.sizes[i] {
+				return n, io.ErrUnexpectedEOF
+			}
+			err = nil
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+func (r *concatReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size()
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative position %d", offset)
+	}
+	r.offset = offset
+	r.current = -1
+	return offset, nil
+}
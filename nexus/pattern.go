@@ -0,0 +1,51 @@
+package nexus
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchPattern reports whether an asset path matches a glob pattern.
+// Patterns use path.Match syntax; in addition "**" matches any number of
+// directories. A pattern without "/" is matched against the file name only,
+// so "*.jar" matches jars at any depth.
+func MatchPattern(pattern string, assetPath string) (bool, error) {
+	pattern = strings.Trim(pattern, "/")
+	assetPath = strings.Trim(assetPath, "/")
+
+	if !strings.Contains(pattern, "/") {
+		return path.Match(pattern, path.Base(assetPath))
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(assetPath, "/"))
+}
+
+// matchSegments matches path segments one by one, expanding "**" to zero or more segments
+func matchSegments(pattern []string, segments []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse consecutive "**" and try every possible split point
+			rest := pattern[1:]
+			for i := 0; i <= len(segments); i++ {
+				matched, err := matchSegments(rest, segments[i:])
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(segments) == 0 {
+			return false, nil
+		}
+
+		matched, err := path.Match(pattern[0], segments[0])
+		if err != nil || !matched {
+			return false, err
+		}
+		pattern = pattern[1:]
+		segments = segments[1:]
+	}
+
+	return len(segments) == 0, nil
+}
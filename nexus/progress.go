@@ -0,0 +1,82 @@
+package nexus
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ProgressFunc receives byte-level progress for a single file transfer.
+// bytesTotal is -1 when the size is not known in advance.
+type ProgressFunc func(name string, bytesDone, bytesTotal int64)
+
+// progressReader reports the number of bytes read through it
+type progressReader struct {
+	reader io.Reader
+	name   string
+	done   int64
+	total  int64
+	report ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.done += int64(n)
+		r.report(r.name, r.done, r.total)
+	}
+	return n, err
+}
+
+// progressReadSeeker is a progressReader that can be rewound, e.g. when a request is retried
+type progressReadSeeker struct {
+	progressReader
+}
+
+func (r *progressReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.reader.(io.Seeker).Seek(offset, whence)
+	if err == nil {
+		r.done = pos
+	}
+	return pos, err
+}
+
+// withProgress wraps reader so that reads are reported to the client's progress callback
+func (c *NexusClient) withProgress(reader io.Reader, name string, total int64) io.Reader {
+	if c.Progress == nil {
+		return reader
+	}
+
+	pr := progressReader{reader: reader, name: name, total: total, report: c.Progress}
+	if _, ok := reader.(io.Seeker); ok {
+		return &progressReadSeeker{pr}
+	}
+	return &pr
+}
+
+// NewTextProgress returns a ProgressFunc that prints a progress line to w
+// every time a file advances by at least one percent
+func NewTextProgress(w io.Writer) ProgressFunc {
+	var mu sync.Mutex
+	lastPercent := map[string]int64{}
+
+	return func(name string, bytesDone, bytesTotal int64) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if bytesTotal <= 0 {
+			fmt.Fprintf(w, "%s: %d bytes\n", name, bytesDone)
+			return
+		}
+
+		percent := bytesDone * 100 / bytesTotal
+		if last, ok := lastPercent[name]; ok && percent == last {
+			return
+		}
+		lastPercent[name] = percent
+		fmt.Fprintf(w, "%s: %3d%% (%d/%d bytes)\n", name, percent, bytesDone, bytesTotal)
+		if bytesDone >= bytesTotal {
+			delete(lastPercent, name)
+		}
+	}
+}
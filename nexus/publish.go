@@ -0,0 +1,156 @@
+package nexus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// publishStagingPrefix starts the name of the temporary directory a publish uploads to
+const publishStagingPrefix = ".publish-"
+
+// PublishOptions controls Publish
+type PublishOptions struct {
+	// Overwrite replaces an existing destination; files of the old content that
+	// are not part of the new one are removed. Without it an existing
+	// destination fails the publish before anything is uploaded.
+	Overwrite bool
+	// Upload filters the uploaded files with its include and exclude patterns
+	Upload UploadOptions
+}
+
+// Publish uploads a local directory to dest so that consumers never see a
+// partial upload: the files are first uploaded to a temporary sibling
+// directory of dest and only moved to dest once every upload succeeded. If an
+// upload fails, the temporary directory is removed and dest is left untouched.
+// It returns the number of published files.
+func (c *NexusClient) Publish(ctx context.Context, repository string, localDir string, dest string, opts PublishOptions) (int, error) {
+	dest = strings.Trim(strings.ReplaceAll(dest, "\\", "/"), "/")
+	if dest == "" {
+		return 0, fmt.Errorf("publish destination must not be the repository root")
+	}
+	staging, err := publishStagingDir(dest)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check the destination before spending time on the upload
+	existing, err := c.GetFilesInDirectory(ctx, repository, dest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list destination: %w", err)
+	}
+	if len(existing) > 0 && !opts.Overwrite {
+		return 0, fmt.Errorf("destination '%s' already contains %d files (use overwrite to replace them)", dest, len(existing))
+	}
+
+	// The staged files are addressed by path rather than found through the
+	// search API, which may not have indexed fresh uploads yet
+	files, err := c.publishFiles(localDir, opts.Upload)
+	if err != nil {
+		return 0, err
+	}
+	if len(files) == 0 {
+		return 0, fmt.Errorf("%w: nothing to publish from '%s'", ErrNoFiles, localDir)
+	}
+
+	c.Logf("Uploading '%s' to staging directory '%s'...", localDir, staging)
+	if _, err := c.UploadDirectoryWithSummary(ctx, repository, localDir, true, staging, opts.Upload); err != nil {
+		c.removeStaging(ctx, repository, staging, files)
+		return 0, fmt.Errorf("upload failed, '%s' left untouched: %w", dest, err)
+	}
+
+	if c.DryRun {
+		c.Logf("Dry run: Would move %d files from '%s' to '%s'", len(files), staging, dest)
+		if len(existing) > 0 {
+			c.Logf("Dry run: Would remove files of '%s' that are not part of the new content", dest)
+		}
+		return len(files), nil
+	}
+
+	c.Logf("Moving '%s' to '%s'...", staging, dest)
+	for _, file := range files {
+		if err := c.CopyFile(ctx, repository, JoinRemotePath(staging, file), JoinRemotePath(dest, file), true); err != nil {
+			c.removeStaging(ctx, repository, staging, files)
+			return 0, fmt.Errorf("failed to move staged files to '%s', it may be partially updated: %w", dest, err)
+		}
+	}
+
+	// Remove files of the previous content that the new one does not replace
+	published := make(map[string]bool, len(files))
+	for _, file := range files {
+		published[JoinRemotePath(dest, file)] = true
+	}
+	var stale []string
+	for _, file := range existing {
+		if filePath := strings.TrimPrefix(file.Path, "/"); !published[filePath] {
+			stale = append(stale, filePath)
+		}
+	}
+	if err := c.deleteFiles(ctx, repository, stale, false); err != nil {
+		c.removeStaging(ctx, repository, staging, files)
+		return 0, fmt.Errorf("published to '%s' but failed to remove old files: %w", dest, err)
+	}
+
+	c.removeStaging(ctx, repository, staging, files)
+	c.Logf("Published %d files to '%s'", len(files), dest)
+	return len(files), nil
+}
+
+// publishFiles lists the files below localDir that pass the upload filters,
+// relative to localDir, in the order the upload walks them
+func (c *NexusClient) publishFiles(localDir string, opts UploadOptions) ([]string, error) {
+	var files []string
+	err := c.walkFiles(localDir, opts.FollowSymlinks, func(_ string, relPath string) error {
+		relPath = filepath.ToSlash(relPath)
+		include, err := opts.matches(relPath)
+		if err != nil {
+			return err
+		}
+		if include {
+			files = append(files, relPath)
+		}
+		return nil
+	}, func(_ string, relDir string) error {
+		relPath, err := opts.placeholderFor(relDir)
+		if err == nil && relPath != "" {
+			files = append(files, relPath)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", localDir, err)
+	}
+	return files, nil
+}
+
+// publishStagingDir returns a unique temporary directory next to dest
+func publishStagingDir(dest string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to create staging directory name: %w", err)
+	}
+	name := publishStagingPrefix + path.Base(dest) + "-" + hex.EncodeToString(suffix)
+	if dir := path.Dir(dest); dir != "." {
+		return dir + "/" + name, nil
+	}
+	return name, nil
+}
+
+// removeStaging deletes the staged files, even when ctx was cancelled, and
+// only warns about files that could not be deleted
+func (c *NexusClient) removeStaging(ctx context.Context, repository string, staging string, files []string) {
+	if c.DryRun {
+		return
+	}
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, JoinRemotePath(staging, file))
+	}
+	if err := c.deleteFiles(context.WithoutCancel(ctx), repository, paths, true); err != nil {
+		c.Warnf("Failed to remove staging directory '%s': %v", staging, err)
+	}
+}
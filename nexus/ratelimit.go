@@ -0,0 +1,163 @@
+package nexus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that caps the bandwidth of every transfer it is
+// attached to. Share one limiter between clients and workers to cap them together.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // bytes per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing bytesPerSecond, or nil (unlimited) for 0
+func NewRateLimiter(bytesPerSecond int64) *RateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	// Allow bursts of a tenth of a second so transfers stay smooth
+	burst := float64(bytesPerSecond) / 10
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{rate: float64(bytesPerSecond), burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until n bytes may be transferred. Callers take the bytes from the
+// bucket up front and sleep off any debt, so concurrent transfers share the rate.
+func (l *RateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	debt := -l.tokens
+	l.mu.Unlock()
+
+	if debt <= 0 {
+		return nil
+	}
+	return sleepWithContext(ctx, time.Duration(debt/l.rate*float64(time.Second)))
+}
+
+// throttledReader limits the rate at which data is read through it
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *RateLimiter
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	// Keep single reads within the burst so the limiter can pace them
+	if limit := int(r.limiter.burst); len(p) > limit {
+		p = p[:limit]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if werr := r.limiter.wait(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// throttledReadSeeker is a throttledReader that can be rewound, e.g. when a request is retried
+type throttledReadSeeker struct {
+	throttledReader
+}
+
+func (r *throttledReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	return r.reader.(io.Seeker).Seek(offset, whence)
+}
+
+// throttle wraps reader so that it is limited by the client's rate limiter
+func (c *NexusClient) throttle(ctx context.Context, reader io.Reader) io.Reader {
+	if c.RateLimit == nil {
+		return reader
+	}
+
+	tr := throttledReader{ctx: ctx, reader: reader, limiter: c.RateLimit}
+	if _, ok := reader.(io.Seeker); ok {
+		return &throttledReadSeeker{tr}
+	}
+	return &tr
+}
+
+// ParseRate parses a transfer rate such as "10MB", "512K", "1.5M/s" or "1048576"
+// into bytes per second. Units are binary (K = 1024 bytes); an empty string or 0 means unlimited.
+func ParseRate(value string) (int64, error) {
+	s := strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(value)), "/S")
+	rate, ok := parseBytes(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid rate '%s': expected a size such as 10MB or 512K", value)
+	}
+	return rate, nil
+}
+
+// ParseSize parses a size such as "10MB", "512K", "1.5G" or "1048576" into
+// bytes. Units are binary (K = 1024 bytes); an empty string means 0.
+func ParseSize(value string) (int64, error) {
+	size, ok := parseBytes(strings.ToUpper(strings.TrimSpace(value)))
+	if !ok {
+		return 0, fmt.Errorf("invalid size '%s': expected a size such as 10MB or 512K", value)
+	}
+	return size, nil
+}
+
+// parseBytes parses an upper case number of bytes with an optional binary unit
+func parseBytes(s string) (int64, bool) {
+	if s == "" {
+		return 0, true
+	}
+
+	multiplier := float64(1)
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30},
+		{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30},
+		{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30},
+		{"B", 1},
+	}
+	for _, unit := range units {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSuffix(s, unit.suffix)
+			multiplier = unit.factor
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || number < 0 {
+		return 0, false
+	}
+	return int64(number * multiplier), true
+}
+
+// FormatBytes formats a byte count with binary units, e.g. "512 B" or "1.5 MB"
+func FormatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
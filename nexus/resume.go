@@ -0,0 +1,164 @@
+package nexus
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// partSuffix is appended to the destination path while a resumable download is in progress
+const partSuffix = ".part"
+
+// errDownloadInterrupted marks failures that can be resumed from the bytes already written
+var errDownloadInterrupted = errors.New("download interrupted")
+
+// resumeDownload downloads into destPath+".part", continuing from any bytes already
+// present, and renames the file to destPath once it is complete. Interrupted transfers
+// are resumed up to the retry policy's attempt count; the part file is kept on failure
+// so that a later run can pick up where this one stopped. When a manifest is set,
+// the sha256 digest of the complete file is returned.
+func (c *NexusClient) resumeDownload(ctx context.Context, downloadURL string, destPath string, checksums map[string]string) (string, error) {
+	if err := createDir(filepath.Dir(destPath)); err != nil {
+		return "", fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	partPath := destPath + partSuffix
+	attempts := c.Retry.attempts()
+	for attempt := 1; ; attempt++ {
+		err := c.downloadPart(ctx, downloadURL, partPath)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errDownloadInterrupted) || attempt >= attempts || ctx.Err() != nil {
+			return "", err
+		}
+
+		wait := c.Retry.backoff(attempt)
+		c.Logf("Download of %s failed: %v (attempt %d/%d), resuming in %s", redactURL(downloadURL), err, attempt, attempts, wait)
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return "", err
+		}
+	}
+
+	// The part file may have been written by several requests, so it is hashed
+	// once it is complete
+	var digest string
+	algorithm, expected := verificationAlgorithm(checksums)
+	if algorithm != "" {
+		actual, err := hashFile(partPath, algorithm)
+		if err != nil {
+			return "", err
+		}
+		if actual != expected {
+			os.Remove(partPath)
+			return "", fmt.Errorf("checksum mismatch: expected %s got %s (%s)", expected, actual, algorithm)
+		}
+		c.Logf("Checksum verified (%s): %s", algorithm, actual)
+		if algorithm == "sha256" {
+			digest = actual
+		}
+	}
+	if c.Manifest != nil && digest == "" {
+		var err error
+		if digest, err = hashFile(partPath, "sha256"); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.Rename(partPath, destPath); err != nil {
+		return "", fmt.Errorf("failed to move downloaded file into place: %w", err)
+	}
+
+	c.Logf("Success file download...")
+	return digest, nil
+}
+
+// downloadPart appends the remaining content of downloadURL to partPath using a
+// Range request. A 200 response means the server ignored the range, so the part
+// file is rewritten from the beginning.
+func (c *NexusClient) downloadPart(ctx context.Context, downloadURL string, partPath string) error {
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	var header http.Header
+	if offset > 0 {
+		c.Logf("Resuming %s from byte %d", partPath, offset)
+		header = http.Header{"Range": []string{fmt.Sprintf("bytes=%d-", offset)}}
+	}
+
+	resp, err := c.makeRequestWithHeaders(ctx, "GET", downloadURL, nil, header)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			os.Remove(partPath)
+			return fmt.Errorf("%w: unexpected content range %q", errDownloadInterrupted, resp.Header.Get("Content-Range"))
+		}
+		flags |= os.O_APPEND
+	case httpStatusOK:
+		if offset > 0 {
+			c.Logf("Server does not support resuming, downloading %s from the beginning", redactURL(downloadURL))
+		}
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The part file already holds the whole content unless the remote file changed
+		if resp.Header.Get("Content-Range") == fmt.Sprintf("bytes */%d", offset) {
+			return nil
+		}
+		os.Remove(partPath)
+		return fmt.Errorf("%w: partial file does not match remote file", errDownloadInterrupted)
+	default:
+		return fmt.Errorf("failed to download file: download failed with status %d", resp.StatusCode)
+	}
+
+	file, err := os.OpenFile(partPath, flags, filePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+
+	reader := c.withProgress(c.throttle(ctx, resp.Body), partPath, resp.ContentLength)
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		if ctx.Err() != nil {
+			return fmt.Errorf("failed to write file content: %w", err)
+		}
+		return fmt.Errorf("%w: %v", errDownloadInterrupted, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write file content: %w", err)
+	}
+
+	return nil
+}
+
+// hashFile computes the hex digest of a local file
+func hashFile(filePath string, algorithm string) (string, error) {
+	hasher, err := newHashForAlgorithm(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for hashing: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
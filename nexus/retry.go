@@ -0,0 +1,136 @@
+package nexus
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// Default retry policy values
+	defaultRetryAttempts  = 3
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryConfig controls how failed HTTP requests are retried
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 (or less) disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles on every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts
+	MaxDelay time.Duration
+	// RetryableStatusCodes lists HTTP status codes that trigger a retry
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryConfig returns the retry policy used by NewNexusClient
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: defaultRetryAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// NoRetryConfig returns a retry policy that performs every request exactly once
+func NoRetryConfig() RetryConfig {
+	return RetryConfig{MaxAttempts: 1}
+}
+
+func (r RetryConfig) attempts() int {
+	if r.MaxAttempts < 1 {
+		return 1
+	}
+	return r.MaxAttempts
+}
+
+func (r RetryConfig) isRetryableStatus(statusCode int) bool {
+	for _, code := range r.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt using exponential backoff with jitter
+func (r RetryConfig) backoff(attempt int) time.Duration {
+	if r.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := r.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if r.MaxDelay > 0 && delay >= r.MaxDelay {
+			delay = r.MaxDelay
+			break
+		}
+	}
+
+	// Add up to 50% jitter so concurrent clients don't retry in lockstep
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	delay += jitter
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return delay
+}
+
+// retryAfter returns the delay requested by the server via Retry-After header,
+// falling back to exponential backoff when the header is absent or invalid
+func (r RetryConfig) retryAfter(resp *http.Response, attempt int) time.Duration {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return r.backoff(attempt)
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return r.backoff(attempt)
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+	} else {
+		return r.backoff(attempt)
+	}
+
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return delay
+}
+
+// sleepWithContext waits for the given duration or until the context is done
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
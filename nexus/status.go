@@ -0,0 +1,85 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrCredentialsRejected is returned by CheckCredentials when the server
+// answers with 401 Unauthorized
+var ErrCredentialsRejected = errors.New("credentials rejected")
+
+// StatusInfo is the health of a Nexus instance as reported by its status endpoints
+type StatusInfo struct {
+	// Available is set when the instance can serve read requests
+	Available bool
+	// Writable is set when the instance also accepts writes; it is only checked on request
+	Writable bool
+	// ResponseTime is how long the availability check took
+	ResponseTime time.Duration
+}
+
+// CheckStatus queries the status endpoints, which need no authentication, and
+// reports whether the instance is available and, when checkWritable is set,
+// writable. An error means the server could not be reached at all.
+func (c *NexusClient) CheckStatus(ctx context.Context, checkWritable bool) (StatusInfo, error) {
+	var info StatusInfo
+
+	start := time.Now()
+	available, err := c.statusOK(ctx, "status")
+	if err != nil {
+		return info, err
+	}
+	info.Available = available
+	info.ResponseTime = time.Since(start)
+
+	if checkWritable && available {
+		if info.Writable, err = c.statusOK(ctx, "status/writable"); err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
+
+// statusOK requests a status endpoint, which answers 200 when the checked
+// condition holds and 503 otherwise
+func (c *NexusClient) statusOK(ctx context.Context, endpoint string) (bool, error) {
+	statusURL := c.apiURL("v1/" + endpoint)
+
+	c.Debugf("REST API request: %s", redactURL(statusURL))
+
+	resp, err := c.makeRequest(ctx, "GET", statusURL, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == httpStatusOK, nil
+}
+
+// CheckCredentials reports whether the server accepts the configured
+// credentials by listing repositories. A 403 response means the credentials
+// are valid but lack the privilege to list repositories, so it is not an error.
+func (c *NexusClient) CheckCredentials(ctx context.Context) error {
+	reposURL := c.repositoriesURL()
+
+	c.Debugf("REST API request: %s", redactURL(reposURL))
+
+	resp, err := c.makeRequest(ctx, "GET", reposURL, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= httpStatusOK && resp.StatusCode < 300, resp.StatusCode == http.StatusForbidden:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrCredentialsRejected
+	default:
+		return fmt.Errorf("repositories request failed with status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,109 @@
+package nexus
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// TLSOptions configures client certificates (mTLS) and trusted certificate authorities.
+// PEM contents take precedence over file paths.
+type TLSOptions struct {
+	ClientCertFile string
+	ClientKeyFile  string
+	CABundleFile   string
+
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	CABundlePEM   []byte
+}
+
+// IsZero reports whether no TLS option is set
+func (o TLSOptions) IsZero() bool {
+	return o.ClientCertFile == "" && o.ClientKeyFile == "" && o.CABundleFile == "" &&
+		len(o.ClientCertPEM) == 0 && len(o.ClientKeyPEM) == 0 && len(o.CABundlePEM) == 0
+}
+
+// Equal reports whether both option sets configure the same certificates
+func (o TLSOptions) Equal(other TLSOptions) bool {
+	return o.ClientCertFile == other.ClientCertFile && o.ClientKeyFile == other.ClientKeyFile && o.CABundleFile == other.CABundleFile &&
+		bytes.Equal(o.ClientCertPEM, other.ClientCertPEM) && bytes.Equal(o.ClientKeyPEM, other.ClientKeyPEM) && bytes.Equal(o.CABundlePEM, other.CABundlePEM)
+}
+
+// NewNexusClientWithTLS creates a new Nexus client with client certificate and CA bundle support
+func NewNexusClientWithTLS(baseURL, username, password string, quiet, dryRun, insecure bool, opts TLSOptions) (*NexusClient, error) {
+	client := NewNexusClient(baseURL, username, password, quiet, dryRun, insecure)
+	if err := client.ConfigureTLS(opts); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// ConfigureTLS applies client certificate and CA bundle settings to the client's transport
+func (c *NexusClient) ConfigureTLS(opts TLSOptions) error {
+	if opts.IsZero() {
+		return nil
+	}
+
+	certPEM, err := readPEM(opts.ClientCertPEM, opts.ClientCertFile, "client certificate")
+	if err != nil {
+		return err
+	}
+	keyPEM, err := readPEM(opts.ClientKeyPEM, opts.ClientKeyFile, "client key")
+	if err != nil {
+		return err
+	}
+	caPEM, err := readPEM(opts.CABundlePEM, opts.CABundleFile, "CA bundle")
+	if err != nil {
+		return err
+	}
+
+	if (len(certPEM) == 0) != (len(keyPEM) == 0) {
+		return fmt.Errorf("client certificate and client key must be specified together")
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if len(certPEM) > 0 {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate and key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(caPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("no valid certificates found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	transport.TLSClientConfig = tlsConfig
+	c.HTTPClient.Transport = transport
+
+	return nil
+}
+
+// readPEM returns inline PEM data or reads it from path
+func readPEM(data []byte, path string, what string) ([]byte, error) {
+	if len(data) > 0 || path == "" {
+		return data, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,40 @@
+package nexus
+
+import "fmt"
+
+// TokenProvider returns a bearer token, e.g. by asking an identity provider for a
+// new one. It is called again when Nexus rejects the current token.
+type TokenProvider func() (string, error)
+
+// bearerToken returns the token to authenticate with, asking c.TokenProvider for
+// one when none is set yet
+func (c *NexusClient) bearerToken() (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.Token == "" && c.TokenProvider != nil {
+		token, err := c.TokenProvider()
+		if err != nil {
+			return "", fmt.Errorf("failed to get token: %w", err)
+		}
+		c.Token = token
+	}
+	return c.Token, nil
+}
+
+// refreshToken replaces the rejected token with a new one from c.TokenProvider.
+// When a concurrent request has already replaced it, the newer token is kept.
+func (c *NexusClient) refreshToken(rejected string) error {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.Token != rejected {
+		return nil
+	}
+	token, err := c.TokenProvider()
+	if err != nil {
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
+	c.Token = token
+	return nil
+}
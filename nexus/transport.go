@@ -0,0 +1,57 @@
+package nexus
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections per host kept
+// open by a client's transport. The net/http default of 2 makes parallel
+// transfers of many small files open a new connection for most requests.
+const DefaultMaxIdleConnsPerHost = 16
+
+// NewTransport creates an HTTP transport that honors HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY and keeps up to maxIdleConnsPerHost idle connections per host alive
+// (DefaultMaxIdleConnsPerHost when 0). The transport may be shared by several
+// clients with the same TLS and proxy settings so they draw from one pool.
+func NewTransport(insecure bool, maxIdleConnsPerHost int) *http.Transport {
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	if transport.MaxIdleConns < maxIdleConnsPerHost {
+		transport.MaxIdleConns = maxIdleConnsPerHost
+	}
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+	return transport
+}
+
+// NewNexusClientWithTransport creates a new Nexus client that sends its requests
+// through transport. Proxy and TLS settings applied to the client change the
+// transport, so clients sharing it must use the same settings.
+func NewNexusClientWithTransport(baseURL, username, password string, quiet, dryRun, insecure bool, transport *http.Transport) *NexusClient {
+	client := NewNexusClient(baseURL, username, password, quiet, dryRun, insecure)
+	client.HTTPClient.Transport = transport
+	return client
+}
+
+// SetConcurrency sets the number of parallel workers for directory transfers and
+// grows the idle connection pool so that every worker can reuse its connection
+func (c *NexusClient) SetConcurrency(concurrency int) {
+	c.Concurrency = concurrency
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport == nil || transport.MaxIdleConnsPerHost >= concurrency {
+		return
+	}
+	transport.MaxIdleConnsPerHost = concurrency
+	if transport.MaxIdleConns < concurrency {
+		transport.MaxIdleConns = concurrency
+	}
+}
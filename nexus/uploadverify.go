@@ -0,0 +1,113 @@
+package nexus
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// uploadHasher computes checksums of an upload body while it is read. Seeking,
+// which makeRequest does before every attempt, restarts the sums, so after a
+// successful request they cover exactly the bytes that were sent. It can only
+// seek when the reader can.
+type uploadHasher struct {
+	reader io.Reader
+	sha256 hash.Hash
+	sha1   hash.Hash
+}
+
+func newUploadHasher(reader io.Reader) *uploadHasher {
+	return &uploadHasher{reader: reader, sha256: sha256.New(), sha1: sha1.New()}
+}
+
+func (h *uploadHasher) Read(p []byte) (int, error) {
+	n, err := h.reader.Read(p)
+	h.sha256.Write(p[:n])
+	h.sha1.Write(p[:n])
+	return n, err
+}
+
+func (h *uploadHasher) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := h.reader.(io.Seeker)
+	if !ok {
+		return 0, errors.New("upload body cannot seek")
+	}
+	h.sha256.Reset()
+	h.sha1.Reset()
+	return seeker.Seek(offset, whence)
+}
+
+// checksums returns the sums of the bytes read since the last seek
+func (h *uploadHasher) checksums() map[string]string {
+	return map[string]string{
+		"sha256": hex.EncodeToString(h.sha256.Sum(nil)),
+		"sha1":   hex.EncodeToString(h.sha1.Sum(nil)),
+	}
+}
+
+// contentChecksums returns the sha256 and sha1 of content
+func contentChecksums(content []byte) map[string]string {
+	sha256Sum := sha256.Sum256(content)
+	sha1Sum := sha1.Sum(content)
+	return map[string]string{
+		"sha256": hex.EncodeToString(sha256Sum[:]),
+		"sha1":   hex.EncodeToString(sha1Sum[:]),
+	}
+}
+
+// verifyUpload compares the checksums of the uploaded bytes with the checksum
+// Nexus recorded for destPath and fails when they differ
+func (c *NexusClient) verifyUpload(ctx context.Context, repository string, destPath string, uploaded map[string]string) error {
+	c.Logf("Uploaded '%s' with sha256 %s", destPath, uploaded["sha256"])
+
+	recorded, err := c.recordedChecksums(ctx, repository, destPath)
+	if err != nil {
+		return fmt.Errorf("failed to verify upload of '%s': %w", destPath, err)
+	}
+
+	algorithm, local, remote := CommonChecksum(uploaded, recorded)
+	if algorithm == "" {
+		c.Warnf("Cannot verify upload of '%s': Nexus reported no sha256 or sha1 checksum", destPath)
+		return nil
+	}
+	if local != remote {
+		return fmt.Errorf("checksum mismatch for uploaded '%s': sent %s %s, Nexus recorded %s", destPath, algorithm, local, remote)
+	}
+
+	c.Logf("Verified upload of '%s' (%s)", destPath, algorithm)
+	return nil
+}
+
+// recordedChecksums returns the checksums Nexus stores for an asset. They are
+// read from the HEAD response headers; the search API is asked when the headers
+// do not carry a sha256.
+func (c *NexusClient) recordedChecksums(ctx context.Context, repository string, assetPath string) (map[string]string, error) {
+	info, err := c.GetAssetInfo(ctx, repository, assetPath)
+	if err != nil {
+		return nil, err
+	}
+	checksums := NormalizeChecksums(info.Checksum)
+	if checksums["sha256"] != "" {
+		return checksums, nil
+	}
+
+	assets, err := c.SearchAssets(ctx, map[string]string{"repository": repository, "name": assetPath})
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range assets {
+		if strings.TrimPrefix(asset.Path, "/") != strings.TrimPrefix(assetPath, "/") {
+			continue
+		}
+		for algorithm, value := range NormalizeChecksums(asset.Checksum) {
+			checksums[algorithm] = value
+		}
+	}
+	return checksums, nil
+}
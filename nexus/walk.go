@@ -0,0 +1,122 @@
+package nexus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// walkFiles calls fn, in lexical order, for every regular file below root with
+// its path and its path relative to root.
+//
+// Symbolic links below root are skipped with a log line unless follow is set.
+// With follow a link is resolved: a linked file is passed to fn under the path
+// of the link and a linked directory is walked like a real one. A directory that
+// is already being walked further up the tree (a link to itself or to one of
+// its parents) is skipped with a warning, so link cycles cannot make the walk
+// endless. Links whose target does not exist are skipped with a warning.
+//
+// When emptyDir is not nil it is called with the path and the root-relative
+// path of every directory, root included, that has no entries at all.
+func (c *NexusClient) walkFiles(root string, follow bool, fn func(path string, relPath string) error, emptyDir func(path string, relPath string) error) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("'%s' is not a directory", root)
+	}
+	w := &fileWalker{client: c, root: root, follow: follow, fn: fn, emptyDir: emptyDir, ancestors: make(map[fileID]bool)}
+	return w.walkDir(root, info)
+}
+
+// fileID identifies a file independently of the path it was reached through:
+// by device and inode where the platform has them, otherwise by resolved path
+type fileID struct {
+	dev  uint64
+	ino  uint64
+	path string
+}
+
+// resolvedFileID identifies a file by its absolute path with all symlinks resolved
+func resolvedFileID(path string) fileID {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return fileID{path: path}
+}
+
+// fileWalker holds the state of a walkFiles call
+type fileWalker struct {
+	client *NexusClient
+	root   string
+	follow bool
+	fn     func(path string, relPath string) error
+	// emptyDir, when not nil, is called for directories without entries
+	emptyDir func(path string, relPath string) error
+	// ancestors holds the directories on the path from root to the directory
+	// being walked
+	ancestors map[fileID]bool
+}
+
+func (w *fileWalker) walkDir(dir string, info os.FileInfo) error {
+	id := fileIdentity(dir, info)
+	if w.ancestors[id] {
+		w.client.Warnf("Skipping '%s': symlink cycle", dir)
+		return nil
+	}
+	w.ancestors[id] = true
+	defer delete(w.ancestors, id)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 && w.emptyDir != nil {
+		relPath, err := filepath.Rel(w.root, dir)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		return w.emptyDir(dir, relPath)
+	}
+	for _, entry := range entries {
+		entryPath := filepath.Join(dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			if !w.follow {
+				w.client.Logf("Skipping symlink '%s' (use --follow-symlinks to upload its target)", entryPath)
+				continue
+			}
+			info, err = os.Stat(entryPath)
+			if err != nil {
+				w.client.Warnf("Skipping symlink '%s': %v", entryPath, err)
+				continue
+			}
+		}
+
+		switch {
+		case info.IsDir():
+			if err := w.walkDir(entryPath, info); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			relPath, err := filepath.Rel(w.root, entryPath)
+			if err != nil {
+				return fmt.Errorf("failed to get relative path: %w", err)
+			}
+			if err := w.fn(entryPath, relPath); err != nil {
+				return err
+			}
+		default:
+			w.client.Logf("Skipping '%s': not a regular file", entryPath)
+		}
+	}
+	return nil
+}